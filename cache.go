@@ -0,0 +1,269 @@
+package gocmcapi
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheSettings configures the cache of info endpoints, see WithCache
+type CacheSettings struct {
+	TTL         time.Duration            // TTL of services not in ServiceTTLs, 0 disables caching them
+	ServiceTTLs map[string]time.Duration // TTL by service, e.g. "server", "vpc", "network", "firewall_vpc"
+	MaxEntries  int                      // Entries kept, the oldest are evicted first. 0 means no limit
+}
+
+// CacheStats are the counters of the cache
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Invalidations uint64 // Entries removed by a mutating call on their resource
+	Evictions     uint64 // Entries removed because of MaxEntries
+	Entries       int
+}
+
+type cacheEntry struct {
+	response string
+	ids      []string
+	expires  time.Time
+	added    time.Time
+}
+
+// responseCache caches the responses of GET requests to info endpoints,
+// mutating calls invalidate the entries of the resource ids they are sent with
+type responseCache struct {
+	settings CacheSettings
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	stats   CacheStats
+
+	// generation is incremented by every invalidation. While GETs are in
+	// flight, invalidated keeps the generation each resource id was last
+	// invalidated at, so a GET started before does not store its response
+	generation  uint64
+	reads       int
+	invalidated map[string]uint64
+}
+
+func newResponseCache(settings CacheSettings) *responseCache {
+	return &responseCache{settings: settings, entries: make(map[string]*cacheEntry), invalidated: make(map[string]uint64)}
+}
+
+// isCacheable reports whether path is a read-only info endpoint
+func isCacheable(path string) bool {
+	return strings.HasSuffix(path, "/info") || strings.HasSuffix(path, "/get_rules") || strings.HasSuffix(path, "/list")
+}
+
+// serviceOf returns the service of path, e.g. server for server_action/resize
+func serviceOf(path string) string {
+	service := strings.SplitN(path, "/", 2)[0]
+	return strings.TrimSuffix(service, "_action")
+}
+
+// ttl returns the TTL of path, 0 if it is not cached
+func (rc *responseCache) ttl(path string) time.Duration {
+	if !isCacheable(path) {
+		return 0
+	}
+	if ttl, ok := rc.settings.ServiceTTLs[serviceOf(path)]; ok {
+		return ttl
+	}
+	return rc.settings.TTL
+}
+
+// cacheKey returns the key of a request from its url and sorted params
+func cacheKey(url string, params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(url)
+	for _, key := range keys {
+		b.WriteString("&" + key + "=" + params[key])
+	}
+	return b.String()
+}
+
+func (rc *responseCache) get(key string) (string, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(rc.entries, key)
+		ok = false
+	}
+	if !ok {
+		rc.stats.Misses++
+		return "", false
+	}
+	rc.stats.Hits++
+	return entry.response, true
+}
+
+// startRead returns the generation a GET starts at, it must be passed to
+// set or endRead
+func (rc *responseCache) startRead() uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.reads++
+	return rc.generation
+}
+
+// endRead ends a GET started at generation without storing its response
+func (rc *responseCache) endRead() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.endReadLocked()
+}
+
+func (rc *responseCache) endReadLocked() {
+	rc.reads--
+	if rc.reads == 0 && len(rc.invalidated) > 0 {
+		rc.invalidated = make(map[string]uint64)
+	}
+}
+
+// set stores the response of a GET started at generation, unless one of its
+// resource ids was invalidated since
+func (rc *responseCache) set(key, response string, ids []string, ttl time.Duration, generation uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	defer rc.endReadLocked()
+	for _, id := range ids {
+		if rc.invalidated[id] > generation {
+			return
+		}
+	}
+	now := time.Now()
+	rc.entries[key] = &cacheEntry{response: response, ids: ids, expires: now.Add(ttl), added: now}
+	for rc.settings.MaxEntries > 0 && len(rc.entries) > rc.settings.MaxEntries {
+		oldest := ""
+		for k, entry := range rc.entries {
+			if oldest == "" || entry.added.Before(rc.entries[oldest].added) {
+				oldest = k
+			}
+		}
+		delete(rc.entries, oldest)
+		rc.stats.Evictions++
+	}
+}
+
+// invalidate removes the entries of the resources ids
+func (rc *responseCache) invalidate(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generation++
+	if rc.reads > 0 {
+		for _, id := range ids {
+			rc.invalidated[id] = rc.generation
+		}
+	}
+	for key, entry := range rc.entries {
+		if containsAny(entry.ids, ids) {
+			delete(rc.entries, key)
+			rc.stats.Invalidations++
+		}
+	}
+}
+
+func (rc *responseCache) purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[string]*cacheEntry)
+}
+
+func (rc *responseCache) getStats() CacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	stats := rc.stats
+	stats.Entries = len(rc.entries)
+	return stats
+}
+
+// resourceIDs returns the values of the id params of a request, i.e. id and
+// *_id, so attaching a volume also invalidates its server
+func resourceIDs(params map[string]string, body map[string]interface{}) []string {
+	var ids []string
+	for key, value := range params {
+		if isIDParam(key) && value != "" {
+			ids = append(ids, value)
+		}
+	}
+	for key, value := range body {
+		if s, ok := value.(string); ok && isIDParam(key) && s != "" {
+			ids = append(ids, s)
+		}
+	}
+	return ids
+}
+
+// nestedActions mutate a resource nested in another one without sending the
+// id of the parent, e.g. a rule of a firewall. They invalidate every cached
+// response of their service, the parent can not be told apart
+var nestedActions = map[string]bool{
+	"firewall_vpc/update_rule": true,
+	"firewall_vpc/delete_rule": true,
+}
+
+// serviceID is the id shared by the cached responses of the service of path
+func serviceID(path string) string {
+	return serviceOf(path) + "/*"
+}
+
+// cachedIDs returns the ids invalidating the cached GET response of path
+func cachedIDs(path string, params map[string]string) []string {
+	return append(resourceIDs(params, nil), serviceID(path))
+}
+
+// mutatedIDs returns the ids invalidated by a mutating request to path
+func mutatedIDs(path string, params map[string]string, body map[string]interface{}) []string {
+	ids := resourceIDs(params, body)
+	if nestedActions[path] {
+		ids = append(ids, serviceID(path))
+	}
+	return ids
+}
+
+func isIDParam(key string) bool {
+	return key == "id" || strings.HasSuffix(key, "_id")
+}
+
+func containsAny(values, wanted []string) bool {
+	for _, v := range values {
+		for _, w := range wanted {
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CacheStats returns the counters of the cache, zero without WithCache
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.getStats()
+}
+
+// PurgeCache removes every cached response
+func (c *Client) PurgeCache() {
+	if c.cache != nil {
+		c.cache.purge()
+	}
+}
+
+// invalidateCache removes the cached responses of the resources of a request
+func (c *Client) invalidateCache(path string, params map[string]string, body map[string]interface{}) {
+	if c.cache != nil {
+		c.cache.invalidate(mutatedIDs(path, params, body))
+	}
+}
@@ -0,0 +1,135 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+func TestCacheHitAndInvalidation(t *testing.T) {
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud, gocmcapi.WithCache(gocmcapi.CacheSettings{TTL: time.Minute}))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Server.Get(s.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := count(cloud, "server/info"); n != 1 {
+		t.Errorf("%d server/info requests, want 1", n)
+	}
+	if stats := c.CacheStats(); stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	// submitting a task invalidates the server, even if nobody waits for it
+	if _, err := c.Server.StopAsync(context.Background(), s.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stats := c.CacheStats(); stats.Entries != 0 || stats.Invalidations != 1 {
+		t.Errorf("stats after StopAsync = %+v", stats)
+	}
+}
+
+func TestCacheSkipsResponseOlderThanInvalidation(t *testing.T) {
+	var mu sync.Mutex
+	name := "old"
+	slow := make(chan struct{})
+	reading := make(chan struct{}, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current := name
+		if r.Method == http.MethodPost {
+			name = "new"
+		}
+		mu.Unlock()
+		if r.URL.Path == "/server/info.json" && current == "old" {
+			// answer with the name read before the rename, after it
+			reading <- struct{}{}
+			<-slow
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"uuid": "s1", "name": current})
+	}))
+	defer ts.Close()
+	c, err := gocmcapi.NewClient("key", gocmcapi.WithBaseURL(ts.URL), gocmcapi.WithCache(gocmcapi.CacheSettings{TTL: time.Minute}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Server.Get("s1")
+	}()
+	<-reading
+	if _, err := c.Server.Rename("s1", "new"); err != nil {
+		t.Fatal(err)
+	}
+	close(slow)
+	<-done
+
+	got, err := c.Server.Get("s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "new" {
+		t.Errorf("name = %s, want new: the stale response was cached", got.Name)
+	}
+}
+
+func TestCacheRuleChangesInvalidateFirewall(t *testing.T) {
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud, gocmcapi.WithCache(gocmcapi.CacheSettings{TTL: time.Minute}))
+	_, status, err := c.VPC.Create("net", "", "hn", "10.0.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err = c.FirewallVPC.Create(status.ResultID, "fw", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	firewallID := status.ResultID
+	for i := 1; i <= 2; i++ {
+		if _, err := c.FirewallVPC.CreateRule(firewallID, i, "0.0.0.0/0", "allow", "tcp", "inbound", "22"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rules, err := c.FirewallVPC.GetRules(firewallID)
+	if err != nil || len(rules) != 2 {
+		t.Fatalf("rules = %v, %v", rules, err)
+	}
+	if _, err := c.FirewallVPC.Get(firewallID); err != nil {
+		t.Fatal(err)
+	}
+
+	// update_rule is only sent with the id of the rule
+	ruleID := rules[0].(map[string]interface{})["id"].(string)
+	if _, err := c.FirewallVPC.UpdateRule(ruleID, 1, "0.0.0.0/0", "allow", "tcp", "inbound", "80"); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = c.FirewallVPC.GetRules(firewallID)
+	if err != nil || len(rules) != 2 || rules[0].(map[string]interface{})["port_range"] != "80" {
+		t.Errorf("rules after UpdateRule = %v, %v, want port 80", rules, err)
+	}
+	if firewall, _ := c.FirewallVPC.Get(firewallID); len(firewall.InboundRules) != 2 || firewall.InboundRules[0].PortRange != "80" {
+		t.Errorf("firewall after UpdateRule = %+v, want port 80", firewall)
+	}
+
+	if err := c.FirewallVPC.DeleteAllRules(firewallID); err != nil {
+		t.Fatal(err)
+	}
+	if rules, err := c.FirewallVPC.GetRules(firewallID); err != nil || len(rules) != 0 {
+		t.Errorf("rules after DeleteAllRules = %v, %v, want none", rules, err)
+	}
+	if firewall, _ := c.FirewallVPC.Get(firewallID); len(firewall.InboundRules) != 0 {
+		t.Errorf("firewall after DeleteAllRules = %+v, want no rules", firewall)
+	}
+}
@@ -0,0 +1,43 @@
+package gocmcapi
+
+// CallOption changes a single long running call
+type CallOption func(*callSettings)
+
+type callSettings struct {
+	timeSettings TimeSettings
+}
+
+// WithTimeSettings overrides the TimeSettings of a call, zero fields keep the
+// settings of the method or of WithEndpointTimeSettings
+func WithTimeSettings(timeSettings TimeSettings) CallOption {
+	return func(s *callSettings) {
+		s.timeSettings = timeSettings
+	}
+}
+
+// override returns t with the non zero fields of o
+func (t TimeSettings) override(o TimeSettings) TimeSettings {
+	if o.Delay != 0 {
+		t.Delay = o.Delay
+	}
+	if o.Interval != 0 {
+		t.Interval = o.Interval
+	}
+	if o.Timeout != 0 {
+		t.Timeout = o.Timeout
+	}
+	return t
+}
+
+// timeSettings returns the TimeSettings of a call to action, the method
+// default overridden by the client table and then by the call options
+func (c *Client) timeSettings(action string, timeSettings TimeSettings, opts []CallOption) TimeSettings {
+	if endpoint, ok := c.endpointTimes[action]; ok {
+		timeSettings = timeSettings.override(endpoint)
+	}
+	settings := callSettings{}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+	return timeSettings.override(settings.timeSettings)
+}
@@ -0,0 +1,185 @@
+package gocmcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by errors.Is when a request fails fast because
+// its circuit breaker is open
+var ErrCircuitOpen = errors.New("Circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+// Circuit breaker states
+const (
+	CircuitClosed   CircuitState = iota // Requests are sent
+	CircuitOpen                         // Requests fail fast with ErrCircuitOpen
+	CircuitHalfOpen                     // One probe request is sent, the others fail fast
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreakerSettings configures the circuit breaker, see WithCircuitBreaker
+type CircuitBreakerSettings struct {
+	FailureThreshold int           // Consecutive failures opening the circuit, 0 for the default
+	Cooldown         time.Duration // Time open before a probe request is sent, 0 for the default
+	// PerService keeps a circuit per service, e.g. server for
+	// server_action/resize, instead of one per api url
+	PerService bool
+	// OnStateChange is called when a circuit changes state. group is the api
+	// url, followed by the service with PerService, e.g.
+	// https://api.cloud.cmctelecom.vn/ver2/server
+	OnStateChange func(group string, from, to CircuitState)
+}
+
+// DefaultCircuitBreakerSettings opens after 5 consecutive failures for 30s
+var DefaultCircuitBreakerSettings = CircuitBreakerSettings{
+	FailureThreshold: 5,
+	Cooldown:         30 * time.Second,
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// circuitBreaker fails requests fast after consecutive transport errors or
+// 5xx/429 answers, a nil circuitBreaker lets every request through
+type circuitBreaker struct {
+	settings CircuitBreakerSettings
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+func newCircuitBreaker(settings CircuitBreakerSettings) *circuitBreaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = DefaultCircuitBreakerSettings.FailureThreshold
+	}
+	if settings.Cooldown <= 0 {
+		settings.Cooldown = DefaultCircuitBreakerSettings.Cooldown
+	}
+	return &circuitBreaker{settings: settings, circuits: make(map[string]*circuit)}
+}
+
+// group returns the circuit of path, clients of other regions share the
+// breaker but not the circuits
+func (b *circuitBreaker) group(apiURL, path string) string {
+	if b.settings.PerService {
+		return apiURL + "/" + serviceOf(path)
+	}
+	return apiURL
+}
+
+// allow returns ErrCircuitOpen when the circuit of path is open, otherwise
+// done must be called with the outcome of the request
+func (b *circuitBreaker) allow(apiURL, path string) (done func(failed bool), err error) {
+	if b == nil {
+		return func(bool) {}, nil
+	}
+	group := b.group(apiURL, path)
+	b.mu.Lock()
+	c, ok := b.circuits[group]
+	if !ok {
+		c = &circuit{}
+		b.circuits[group] = c
+	}
+	from := c.state
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= b.settings.Cooldown {
+		c.state = CircuitHalfOpen
+	}
+	probe := false
+	switch {
+	case c.state == CircuitOpen, c.state == CircuitHalfOpen && c.probing:
+		err = fmt.Errorf("%w: %s", ErrCircuitOpen, path)
+	case c.state == CircuitHalfOpen:
+		c.probing = true
+		probe = true
+	}
+	to := c.state
+	b.mu.Unlock()
+	b.notify(group, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return func(failed bool) { b.done(group, probe, failed) }, nil
+}
+
+func (b *circuitBreaker) done(group string, probe, failed bool) {
+	b.mu.Lock()
+	c := b.circuits[group]
+	from := c.state
+	if probe {
+		c.probing = false
+	}
+	switch {
+	case !failed:
+		c.failures = 0
+		c.state = CircuitClosed
+	case probe || c.state == CircuitClosed && c.failures+1 >= b.settings.FailureThreshold:
+		c.failures = 0
+		c.state = CircuitOpen
+		c.openedAt = time.Now()
+	case c.state == CircuitClosed:
+		c.failures++
+	}
+	to := c.state
+	b.mu.Unlock()
+	b.notify(group, from, to)
+}
+
+func (b *circuitBreaker) notify(group string, from, to CircuitState) {
+	if from != to && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(group, from, to)
+	}
+}
+
+// state returns the state of the circuit of path
+func (b *circuitBreaker) state(apiURL, path string) CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c, ok := b.circuits[b.group(apiURL, path)]; ok {
+		return c.state
+	}
+	return CircuitClosed
+}
+
+// isCircuitFailure reports whether a request counts as a failure of its
+// circuit, cancelled requests and client errors do not
+func isCircuitFailure(ctx context.Context, statusCode int, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+}
+
+// CircuitState returns the state of the circuit breaker of path, e.g.
+// server/info, CircuitClosed without WithCircuitBreaker
+func (c *Client) CircuitState(path string) CircuitState {
+	return c.breaker.state(c.apiURL, path)
+}
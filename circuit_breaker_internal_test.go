@@ -0,0 +1,76 @@
+package gocmcapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func failTimes(t *testing.T, b *circuitBreaker, apiURL string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		done, err := b.allow(apiURL, "server/info")
+		if err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+		done(true)
+	}
+}
+
+func TestCircuitBreakerDefaultCooldown(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 3})
+	if b.settings.Cooldown != DefaultCircuitBreakerSettings.Cooldown {
+		t.Errorf("cooldown = %s, want %s", b.settings.Cooldown, DefaultCircuitBreakerSettings.Cooldown)
+	}
+	failTimes(t, b, "https://a", 3)
+	for i := 0; i < 3; i++ {
+		if _, err := b.allow("https://a", "server/info"); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request after opening: %v, want ErrCircuitOpen", err)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	var changes []CircuitState
+	b := newCircuitBreaker(CircuitBreakerSettings{
+		FailureThreshold: 1,
+		Cooldown:         10 * time.Millisecond,
+		OnStateChange:    func(group string, from, to CircuitState) { changes = append(changes, to) },
+	})
+	failTimes(t, b, "https://a", 1)
+	time.Sleep(20 * time.Millisecond)
+	probe, err := b.allow("https://a", "server/info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.allow("https://a", "server/info"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("request during the probe: %v, want ErrCircuitOpen", err)
+	}
+	probe(false)
+	if state := b.state("https://a", "server/info"); state != CircuitClosed {
+		t.Errorf("state after the probe = %s", state)
+	}
+	want := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if len(changes) != len(want) {
+		t.Fatalf("state changes = %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("state changes = %v, want %v", changes, want)
+		}
+	}
+}
+
+func TestCircuitBreakerByAPIURL(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 2, PerService: true})
+	failTimes(t, b, "https://hn", 2)
+	if state := b.state("https://hn", "server/info"); state != CircuitOpen {
+		t.Errorf("hn server circuit = %s, want open", state)
+	}
+	if state := b.state("https://hn", "volume/info"); state != CircuitClosed {
+		t.Errorf("hn volume circuit = %s, want closed", state)
+	}
+	if _, err := b.allow("https://hcm", "server/info"); err != nil {
+		t.Errorf("hcm server circuit: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+func TestCircuitBreakerFailsFast(t *testing.T) {
+	sgCloud := fakecloud.New()
+	sgServer := httptest.NewServer(sgCloud)
+	defer sgServer.Close()
+	cloud := fakecloud.New(fakecloud.WithRegions([]gocmcapi.Region{{ID: "hn"}, {ID: "sg", APIURL: sgServer.URL + fakecloud.BasePath}}))
+	c := newFakeClient(t, cloud,
+		gocmcapi.WithRetryPolicy(gocmcapi.NoRetryPolicy),
+		gocmcapi.WithCircuitBreaker(gocmcapi.CircuitBreakerSettings{FailureThreshold: 2}))
+	sg, err := c.ForRegion(context.Background(), "sg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+	cloud.InjectFault(fakecloud.Fault{Path: "server/info", StatusCode: 503})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Server.Get(s.ID); err == nil || errors.Is(err, gocmcapi.ErrCircuitOpen) {
+			t.Fatalf("request %d: %v, want a 503", i+1, err)
+		}
+	}
+	if _, err := c.Server.Get(s.ID); !errors.Is(err, gocmcapi.ErrCircuitOpen) {
+		t.Errorf("third request: %v, want ErrCircuitOpen", err)
+	}
+	if state := c.CircuitState("server/info"); state != gocmcapi.CircuitOpen {
+		t.Errorf("CircuitState = %s, want open", state)
+	}
+
+	// the circuit of another region is not open
+	s = sgCloud.AddServer(gocmcapi.Server{Name: "web"})
+	if _, err := sg.Server.Get(s.ID); err != nil {
+		t.Errorf("request to sg: %v", err)
+	}
+}
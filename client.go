@@ -1,10 +1,15 @@
 package gocmcapi
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,6 +51,40 @@ type Client struct {
 	FirewallDirect FirewallDirectService
 	FirewallVPC    FirewallVPCService
 	Snapshot       SnapshotService
+	Region         RegionService
+
+	// settings from ClientOption
+	httpClient      *http.Client
+	transport       http.RoundTripper
+	userAgent       string
+	requestTimeout  time.Duration
+	connPool        ConnPoolSettings
+	retryPolicy     RetryPolicy
+	rateLimit       RateLimit
+	taskPollLimit   *RateLimit // nil uses rateLimit
+	limiter         *limiter
+	taskPollLimiter *limiter
+	logger          Logger
+	authMode        AuthMode
+	pollScale       float64
+	region          string
+	regionEndpoints map[string]string
+	tracer          Tracer
+	metrics         Metrics
+	dryRun          bool
+	plan            *dryRunPlan
+	reconcilers     map[string]Reconciler
+	submissions     *submissions
+	cache           *responseCache
+	breaker         *circuitBreaker
+	journal         TaskJournal
+	taskObserver    TaskObserver
+	endpointTimes   map[string]TimeSettings
+
+	beforeRequestHooks []BeforeRequestHook
+	afterResponseHooks []AfterResponseHook
+
+	rest *resty.Client
 }
 
 // APIError is return when there are an error when call api
@@ -53,6 +92,29 @@ type APIError struct {
 	Success   bool   `json:"success"`
 	ErrorCode int    `json:"error_code"`
 	ErrorText string `json:"error_text"`
+
+	StatusCode int    `json:"-"` // Http status of the response
+	Method     string `json:"-"` // Http method of the request
+	Endpoint   string `json:"-"` // Api path, e.g. server/info
+	Body       string `json:"-"` // Raw response body
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Error %d: %s", e.ErrorCode, e.ErrorText)
+}
+
+// Unwrap returns ErrNotFound, ErrPermissionDenied or ErrCommon depending on
+// the error code, so errors.Is(err, ErrNotFound) can be used
+func (e *APIError) Unwrap() error {
+	for _, code := range []int{e.ErrorCode, e.StatusCode} {
+		switch code {
+		case http.StatusNotFound:
+			return ErrNotFound
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ErrPermissionDenied
+		}
+	}
+	return ErrCommon
 }
 
 // Timeout is timeout info for a long task
@@ -63,11 +125,45 @@ type Timeout struct {
 }
 
 // NewClient creates new CMC Cloud Api client.
-func NewClient(apikey string) (*Client, error) {
+func NewClient(apikey string, opts ...ClientOption) (*Client, error) {
 	c := &Client{
-		apiURL: defaultAPIURL,
-		apiKey: apikey,
+		apiURL:      defaultAPIURL,
+		apiKey:      apikey,
+		connPool:    DefaultConnPoolSettings,
+		retryPolicy: DefaultRetryPolicy,
+		logger:      NopLogger{},
+		pollScale:   1,
+		tracer:      nopTracer{},
+		metrics:     nopMetrics{},
+		plan:        &dryRunPlan{},
+		submissions: newSubmissions(),
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
 	}
+	if c.region != "" {
+		endpoint := c.regionEndpoints[c.region]
+		if endpoint == "" {
+			return nil, errNoRegionEndpoint(c.region)
+		}
+		c.apiURL = strings.TrimRight(endpoint, "/")
+	}
+	c.limiter = newLimiter(c.rateLimit)
+	pollLimit := c.rateLimit
+	if c.taskPollLimit != nil {
+		pollLimit = *c.taskPollLimit
+	}
+	c.taskPollLimiter = newLimiter(pollLimit)
+	c.logger = redactingLogger{logger: c.logger, secret: c.apiKey}
+	c.rest = c.newRestyClient()
+	c.initServices()
+	return c, nil
+}
+
+// initServices creates the services of c, they keep a pointer to c
+func (c *Client) initServices() {
 	c.Server = &server{client: c}
 	c.Task = &task{client: c}
 	c.Volume = &volume{client: c}
@@ -77,84 +173,323 @@ func NewClient(apikey string) (*Client, error) {
 	c.FirewallDirect = &firewalldirect{client: c}
 	c.FirewallVPC = &firewallvpc{client: c}
 	c.Snapshot = &snapshot{client: c}
-	return c, nil
+	c.Region = &region{client: c}
 }
 
-func (c *Client) createRequest(params map[string]string) *resty.Request {
-	client := resty.New()
-
-	if params == nil {
-		params = make(map[string]string)
+// newRestyClient creates the resty client shared by every request of c, so
+// keep-alive connections and TLS sessions are reused, e.g. while polling a task
+func (c *Client) newRestyClient() *resty.Client {
+	var client *resty.Client
+	if c.httpClient != nil {
+		// copy it so resty settings below do not modify the caller's client
+		httpClient := *c.httpClient
+		client = resty.NewWithClient(&httpClient)
+	} else {
+		client = resty.NewWithClient(&http.Client{Transport: c.connPool.newTransport()})
+	}
+	if c.transport != nil {
+		client.SetTransport(c.transport)
+	}
+	if c.requestTimeout > 0 {
+		client.SetTimeout(c.requestTimeout)
 	}
+	if c.userAgent != "" {
+		client.SetHeader("User-Agent", c.userAgent)
+	}
+	client.SetPreRequestHook(rewindableBody)
+	return client
+}
 
-	//c.apiKey = "vTMSG7F9mFKnNRYIz8eA9N9XrHJ4zP"
-	params["api_key"] = c.apiKey
+// rewindableBody lets net/http resend the body of a request, e.g. a POST
+// with an Idempotency-Key whose connection was dropped. The GetBody set by
+// resty returns the part of the body which was not sent yet
+func rewindableBody(_ *resty.Client, req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return nil
+}
 
+func (c *Client) createRequest(ctx context.Context, params map[string]string) *resty.Request {
 	//var obj interface{}
-	request := client.R().
+	request := c.rest.R().
+		SetContext(ctx).
 		SetHeader("Accept", "application/json").
 		SetAuthToken(c.apiKey).
 		SetError(&APIError{}).
 		SetQueryParams(params)
+	if c.authMode == AuthModeHeaderAndQuery {
+		request.SetQueryParam("api_key", c.apiKey)
+	}
 
 	return request
 }
-func (c *Client) parseResponse(response *resty.Response, err error) (string, error) {
+func (c *Client) parseResponse(method, path string, response *resty.Response, err error) (string, error) {
+	if response == nil {
+		return "", err
+	}
 	restext := response.String() // fmt.Sprint(response)
 	if err != nil {
 		return restext, err
 	}
-	if response.Error() != nil {
-		apiError := response.Error().(*APIError)
-		if apiError != nil {
-			if apiError.ErrorCode == 0 {
-				apiError.ErrorCode = response.StatusCode()
-			}
-			return restext, fmt.Errorf("Error %d: %s", apiError.ErrorCode, apiError.ErrorText)
+	if response.IsError() {
+		apiError, _ := response.Error().(*APIError)
+		if apiError == nil {
+			apiError = &APIError{}
 		}
+		if apiError.ErrorCode == 0 {
+			apiError.ErrorCode = response.StatusCode()
+		}
+		apiError.StatusCode = response.StatusCode()
+		apiError.Method = method
+		apiError.Endpoint = path
+		apiError.Body = restext
+		return restext, apiError
 	}
 
-	if strings.Contains(restext, "error_code") && strings.Contains(restext, "error_text") {
-		var apiError APIError
-		json.Unmarshal([]byte(restext), &apiError)
-		return restext, fmt.Errorf("Error %d: %s", apiError.ErrorCode, apiError.ErrorText)
+	if apiError := apiErrorInBody(restext); apiError != nil {
+		apiError.StatusCode = response.StatusCode()
+		apiError.Method = method
+		apiError.Endpoint = path
+		apiError.Body = restext
+		return restext, apiError
 	}
 	return restext, err
 }
 
+// apiErrorInBody returns the api error of a successful response whose top
+// level error_code is non zero, nil otherwise
+func apiErrorInBody(body string) *APIError {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal([]byte(body), &fields) != nil {
+		return nil
+	}
+	raw, ok := fields["error_code"]
+	if !ok {
+		return nil
+	}
+	var code float64
+	if json.Unmarshal(raw, &code) != nil {
+		// a code sent as a string, e.g. "404"
+		var text string
+		if json.Unmarshal(raw, &text) != nil {
+			return nil
+		}
+		code, _ = strconv.ParseFloat(text, 64)
+	}
+	if code == 0 {
+		return nil
+	}
+	var errorText string
+	json.Unmarshal(fields["error_text"], &errorText)
+	return &APIError{ErrorCode: int(code), ErrorText: errorText}
+}
+
+// execute sends a request, retrying it according to the client's RetryPolicy
+func (c *Client) execute(ctx context.Context, method, path string, params map[string]string, body map[string]interface{}) (*resty.Response, error) {
+	limiter := c.limiter
+	if path == taskStatusPath {
+		limiter = c.taskPollLimiter
+	}
+	for attempt := 1; ; attempt++ {
+		release, err := limiter.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		info := &RequestInfo{
+			Method:  method,
+			Path:    path,
+			Params:  copyParams(params),
+			Body:    copyBody(body),
+			Attempt: attempt,
+			Header:  http.Header{},
+		}
+		if key := IdempotencyKeyFromContext(ctx); key != "" && isMutating(ctx, method) {
+			info.Header.Set(IdempotencyKeyHeader, key)
+		}
+		if err := c.runBeforeRequestHooks(ctx, info); err != nil {
+			release()
+			return nil, err
+		}
+		// hooks may have changed the path, params or body
+		url := c.apiURL + "/" + info.Path + ".json"
+
+		done, err := c.breaker.allow(c.apiURL, path)
+		if err != nil {
+			release()
+			return nil, err
+		}
+
+		attemptCtx, span := c.tracer.Start(ctx, method+" "+path)
+		span.SetAttribute(AttrEndpoint, path)
+		span.SetAttribute(AttrMethod, method)
+		span.SetAttribute(AttrAttempt, attempt)
+		if id := resourceID(params, body); id != "" {
+			span.SetAttribute(AttrResourceID, id)
+		}
+
+		request := c.createRequest(traceSent(attemptCtx), info.Params)
+		for key, values := range info.Header {
+			for _, value := range values {
+				request.Header.Add(key, value)
+			}
+		}
+		if method == http.MethodPost || method == http.MethodPut {
+			request.SetBody(info.Body)
+		}
+		start := time.Now()
+		resp, err := request.Execute(method, url)
+		err = redactError(err)
+		release()
+		duration := time.Since(start)
+		spanErr := err
+		statusCode := 0
+		if resp != nil && resp.RawResponse != nil {
+			statusCode = resp.StatusCode()
+			span.SetAttribute(AttrStatusCode, statusCode)
+			markSent(ctx)
+			if spanErr == nil && resp.IsError() {
+				spanErr = &APIError{ErrorCode: resp.StatusCode(), ErrorText: http.StatusText(resp.StatusCode()), StatusCode: resp.StatusCode(), Method: method, Endpoint: path}
+			}
+		}
+		span.End(spanErr)
+		done(isCircuitFailure(ctx, statusCode, err))
+		c.metrics.ObserveRequest(method, path, statusCode, duration)
+
+		if len(c.afterResponseHooks) > 0 {
+			respInfo := &ResponseInfo{Request: info, Duration: duration, Err: err}
+			if resp != nil {
+				respInfo.StatusCode = resp.StatusCode()
+				respInfo.Body = resp.String()
+			}
+			c.runAfterResponseHooks(ctx, respInfo)
+		}
+
+		if !c.retryPolicy.shouldRetry(ctx, method, attempt, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retryPolicy.wait(attempt, resp)
+		if err != nil {
+			c.logger.Debug("Request failed, retrying", "method", method, "path", path, "attempt", attempt, "error", err, "wait", wait)
+		} else {
+			c.logger.Debug("Request failed, retrying", "method", method, "path", path, "attempt", attempt, "status", resp.StatusCode(), "wait", wait)
+		}
+		select {
+		case <-ctx.Done():
+			return resp, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// request sends a request and detects api errors in the response, every http verb goes through it
+func (c *Client) request(ctx context.Context, method, path string, params map[string]string, body map[string]interface{}) (string, error) {
+	if c.dryRun && isMutating(ctx, method) {
+		return c.recordDryRun(method, path, params, body)
+	}
+	if isMutating(ctx, method) && IdempotencyKeyFromContext(ctx) == "" {
+		// the same key is sent on every attempt of execute
+		ctx = WithIdempotencyKey(ctx, NewIdempotencyKey())
+	}
+	if isMutating(ctx, method) {
+		defer c.invalidateCache(path, params, body)
+	}
+
+	key, ttl, generation := "", time.Duration(0), uint64(0)
+	if c.cache != nil && method == http.MethodGet {
+		if ttl = c.cache.ttl(path); ttl > 0 {
+			key = cacheKey(c.apiURL+"/"+path, params)
+			if response, ok := c.cache.get(key); ok {
+				return response, nil
+			}
+			generation = c.cache.startRead()
+		}
+	}
+
+	resp, err := c.execute(ctx, method, path, params, body)
+	response, err := c.parseResponse(method, path, resp, err)
+	if key != "" {
+		if err == nil {
+			c.cache.set(key, response, cachedIDs(path, params), ttl, generation)
+		} else {
+			c.cache.endRead()
+		}
+	}
+	return response, err
+}
+
 // Get Request, return resty Response
 func (c *Client) Get(path string, params map[string]string) (string, error) {
-	resp, err := c.createRequest(params).Get(c.apiURL + "/" + path + ".json")
+	return c.GetWithContext(context.Background(), path, params)
+}
 
-	restext := fmt.Sprint(resp)
-	return restext, err
+// GetWithContext is Get with a context that can cancel the request
+func (c *Client) GetWithContext(ctx context.Context, path string, params map[string]string) (string, error) {
+	return c.request(ctx, http.MethodGet, path, params, nil)
 }
 
 // Post request
 func (c *Client) Post(path string, params map[string]interface{}) (string, error) {
-	// fmt.Println(c.apiURL+"/"+path+".json", params)
-	resp, err := c.createRequest(nil).SetBody(params).Post(c.apiURL + "/" + path + ".json")
-	return c.parseResponse(resp, err)
+	return c.PostWithContext(context.Background(), path, params)
+}
+
+// PostWithContext is Post with a context that can cancel the request
+func (c *Client) PostWithContext(ctx context.Context, path string, params map[string]interface{}) (string, error) {
+	return c.request(ctx, http.MethodPost, path, nil, params)
 }
 
 // Put request
 func (c *Client) Put(path string, params map[string]interface{}) (string, error) {
-	resp, err := c.createRequest(nil).SetBody(params).Put(c.apiURL + "/" + path + ".json")
+	return c.PutWithContext(context.Background(), path, params)
+}
 
-	restext := fmt.Sprint(resp)
-	return restext, err
+// PutWithContext is Put with a context that can cancel the request
+func (c *Client) PutWithContext(ctx context.Context, path string, params map[string]interface{}) (string, error) {
+	return c.request(ctx, http.MethodPut, path, nil, params)
 }
 
 // Delete request
 func (c *Client) Delete(path string, params map[string]string) (string, error) {
-	resp, err := c.createRequest(params).Delete(c.apiURL + "/" + path + ".json")
+	return c.DeleteWithContext(context.Background(), path, params)
+}
 
-	restext := fmt.Sprint(resp)
-	return restext, err
+// DeleteWithContext is Delete with a context that can cancel the request
+func (c *Client) DeleteWithContext(ctx context.Context, path string, params map[string]string) (string, error) {
+	return c.request(ctx, http.MethodDelete, path, params, nil)
 }
 
 // LongTask execute a action that return a task
-func (c *Client) LongTask(action string, id string, params map[string]interface{}, timeSettings TimeSettings) (TaskStatus, error) {
+func (c *Client) LongTask(action string, id string, params map[string]interface{}, timeSettings TimeSettings, opts ...CallOption) (TaskStatus, error) {
+	return c.LongTaskWithContext(context.Background(), action, id, params, timeSettings, opts...)
+}
+
+// LongTaskWithContext is LongTask with a context, cancelling it stops waiting for the task
+func (c *Client) LongTaskWithContext(ctx context.Context, action string, id string, params map[string]interface{}, timeSettings TimeSettings, opts ...CallOption) (TaskStatus, error) {
+	handle, err := c.LongTaskAsync(ctx, action, id, params, timeSettings, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// LongTaskAsync submits a action that return a task, without waiting for it
+func (c *Client) LongTaskAsync(ctx context.Context, action string, id string, params map[string]interface{}, timeSettings TimeSettings, opts ...CallOption) (handle *TaskHandle, err error) {
+	timeSettings = c.timeSettings(action, timeSettings, opts)
+	ctx, span := c.startCallSpan(ctx, action, id)
+	defer func() {
+		// the handle ends the span once the task finished
+		if handle == nil {
+			span.End(err)
+		}
+	}()
+
 	if params == nil {
 		params = make(map[string]interface{})
 	}
@@ -162,23 +497,53 @@ func (c *Client) LongTask(action string, id string, params map[string]interface{
 		params["id"] = id
 	}
 
-	jsonStr, err := c.Post(action, params)
+	jsonStr, resultID, err := c.submit(ctx, action, params)
 	var task Task
-	var taskResponse TaskStatus
 	json.Unmarshal([]byte(jsonStr), &task)
+	span.SetAttribute(AttrTaskID, task.TaskID)
 
 	if err != nil {
-		return taskResponse, err
+		return nil, err
 	}
-	taskResponse, err = c.waitForTaskFinished(task.TaskID, timeSettings)
-	if err != nil {
-		return taskResponse, fmt.Errorf("Error perform action %s: %s, params: %+v", action, err, params)
+	handle = newTaskHandle(c, action, task.TaskID, timeSettings)
+	handle.traceCtx, handle.span = ctx, span
+	handle.ids = mutatedIDs(action, nil, params)
+	handle.submitted()
+	handle.wrap = func(err error) error {
+		return fmt.Errorf("Error perform action %s: %w, params: %+v", action, err, redactParams(params))
+	}
+	if resultID != "" {
+		handle.finish(TaskStatus{Command: action, Status: "DONE", ResultID: resultID}, nil)
+	} else if c.dryRun {
+		handle.finish(dryRunTaskStatus(action, id), nil)
+	} else {
+		c.journalAdd(handle, id)
 	}
-	return taskResponse, err
+	return handle, nil
 }
 
 // LongDeleteTask execute a action that return a task
-func (c *Client) LongDeleteTask(action string, id string, params map[string]string, timeSettings TimeSettings) (TaskStatus, error) {
+func (c *Client) LongDeleteTask(action string, id string, params map[string]string, timeSettings TimeSettings, opts ...CallOption) (TaskStatus, error) {
+	return c.LongDeleteTaskWithContext(context.Background(), action, id, params, timeSettings, opts...)
+}
+
+// LongDeleteTaskWithContext is LongDeleteTask with a context, cancelling it stops waiting for the task
+func (c *Client) LongDeleteTaskWithContext(ctx context.Context, action string, id string, params map[string]string, timeSettings TimeSettings, opts ...CallOption) (TaskStatus, error) {
+	handle, err := c.LongDeleteTaskAsync(ctx, action, id, params, timeSettings, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// LongDeleteTaskAsync submits a delete action that return a task, without waiting for it
+func (c *Client) LongDeleteTaskAsync(ctx context.Context, action string, id string, params map[string]string, timeSettings TimeSettings, opts ...CallOption) (handle *TaskHandle, err error) {
+	timeSettings = c.timeSettings(action, timeSettings, opts)
+	ctx, span := c.startCallSpan(ctx, action, id)
+	defer func() {
+		// the handle ends the span once the task finished
+		if handle == nil {
+			span.End(err)
+		}
+	}()
+
 	if params == nil {
 		params = make(map[string]string)
 	}
@@ -186,23 +551,52 @@ func (c *Client) LongDeleteTask(action string, id string, params map[string]stri
 		params["id"] = id
 	}
 
-	jsonStr, err := c.Delete(action, params)
+	jsonStr, err := c.DeleteWithContext(ctx, action, params)
 	var task Task
-	var taskResponse TaskStatus
 	json.Unmarshal([]byte(jsonStr), &task)
+	span.SetAttribute(AttrTaskID, task.TaskID)
 
 	if err != nil {
-		return taskResponse, err
+		return nil, err
 	}
-	taskResponse, err = c.waitForTaskFinished(task.TaskID, timeSettings)
-	if err != nil {
-		return taskResponse, fmt.Errorf("Error perform action %s: %s, params: %+v", action, err, params)
+	handle = newTaskHandle(c, action, task.TaskID, timeSettings)
+	handle.traceCtx, handle.span = ctx, span
+	handle.ids = mutatedIDs(action, params, nil)
+	handle.submitted()
+	handle.wrap = func(err error) error {
+		return fmt.Errorf("Error perform action %s: %w, params: %+v", action, err, redactStringParams(params))
+	}
+	if c.dryRun {
+		handle.finish(dryRunTaskStatus(action, id), nil)
+	} else {
+		c.journalAdd(handle, id)
 	}
-	return taskResponse, err
+	return handle, nil
 }
 
 // Order create an resource order
-func (c *Client) Order(action string, id string, params map[string]interface{}, timeSettings TimeSettings) (OrderResponse, TaskStatus, error) {
+func (c *Client) Order(action string, id string, params map[string]interface{}, timeSettings TimeSettings, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return c.OrderWithContext(context.Background(), action, id, params, timeSettings, opts...)
+}
+
+// OrderWithContext is Order with a context, cancelling it stops waiting for the task
+func (c *Client) OrderWithContext(ctx context.Context, action string, id string, params map[string]interface{}, timeSettings TimeSettings, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := c.OrderAsync(ctx, action, id, params, timeSettings, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// OrderAsync submits an resource order, without waiting for its task. The
+// handle is also returned when the order is not paid
+func (c *Client) OrderAsync(ctx context.Context, action string, id string, params map[string]interface{}, timeSettings TimeSettings, opts ...CallOption) (handle *TaskHandle, err error) {
+	timeSettings = c.timeSettings(action, timeSettings, opts)
+	ctx, span := c.startCallSpan(ctx, action, id)
+	defer func() {
+		// the handle ends the span once the task finished
+		if handle == nil {
+			span.End(err)
+		}
+	}()
+
 	if params == nil {
 		params = make(map[string]interface{})
 	}
@@ -210,26 +604,46 @@ func (c *Client) Order(action string, id string, params map[string]interface{},
 		params["id"] = id
 	}
 
-	jsonStr, err := c.Post(action, params)
-	var order OrderResponse
-	var taskStatus TaskStatus
-
+	jsonStr, resultID, err := c.submit(ctx, action, params)
 	if err != nil {
-		return order, taskStatus, fmt.Errorf("Error perform action %s: %s, params: %+v", action, err, params)
+		var unknownErr *UnknownOutcomeError
+		if errors.As(err, &unknownErr) || ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Error perform action %s: %w, params: %+v", action, err, redactParams(params))
 	}
 
-	json.Unmarshal([]byte(jsonStr), &order)
+	var order OrderResponse
+	if resultID != "" {
+		order.Paid = true
+	} else {
+		json.Unmarshal([]byte(jsonStr), &order)
+	}
+	span.SetAttribute(AttrTaskID, order.TaskID)
+	handle = newTaskHandle(c, action, order.TaskID, timeSettings)
+	handle.traceCtx, handle.span = ctx, span
+	handle.Order = order
+	handle.ids = mutatedIDs(action, nil, params)
+	handle.submitted()
+	handle.wrap = func(err error) error {
+		return fmt.Errorf("Error perform action %s with task id (%s): %w", action, order.TaskID, err)
+	}
 	if !order.Paid {
-		return order, taskStatus, fmt.Errorf("Error perform action %s cause order is not paid, input = %+v, response = %s", action, params, jsonStr)
+		c.metrics.IncOrderUnpaid(action)
+		err = fmt.Errorf("Error perform action %s cause order is not paid, input = %+v, response = %s", action, redactParams(params), jsonStr)
 		//errors.New("Can not perform this action cause of payment failed, connect to CMC administrator for your advice")
+		handle.finish(TaskStatus{}, err)
+		return handle, err
 	}
 
-	taskStatus, err = c.waitForTaskFinished(order.TaskID, timeSettings)
-	if err != nil {
-		return order, taskStatus, fmt.Errorf("Error perform action %s with task id (%s): %s", action, order.TaskID, err)
+	if resultID != "" {
+		handle.finish(TaskStatus{Command: action, Status: "DONE", ResultID: resultID}, nil)
+	} else if c.dryRun {
+		handle.finish(dryRunTaskStatus(action, id), nil)
+	} else {
+		c.journalAdd(handle, id)
 	}
-
-	return order, taskStatus, err
+	return handle, nil
 }
 
 // TimeSettings object
@@ -257,41 +671,107 @@ var HalfDayTimeSettings = TimeSettings{Delay: 60, Interval: 60, Timeout: 12 * 60
 // OneDayTimeSettings for long task like take snapshot
 var OneDayTimeSettings = TimeSettings{Delay: 60, Interval: 60, Timeout: 24 * 60 * 60}
 
-func (c *Client) waitForTaskFinished(taskID string, timeSettings TimeSettings) (TaskStatus, error) {
-	log.Printf("[INFO] Waiting for server with task id (%s) to be created", taskID)
+// waitForTaskFinished polls a task until it is DONE, action is the action which
+// started it, empty when unknown
+func (c *Client) waitForTaskFinished(ctx context.Context, action string, taskID string, timeSettings TimeSettings) (status TaskStatus, err error) {
+	ctx, span := c.tracer.Start(ctx, "wait task")
+	span.SetAttribute(AttrTaskID, taskID)
+	polls := &taskPolls{}
+	start := time.Now()
+	defer func() {
+		count, last := polls.get()
+		// the action is known even when no poll succeeded
+		command := action
+		if command == "" {
+			command = last.Command
+		}
+		c.metrics.ObserveTaskWait(command, taskOutcome(ctx, last, err), time.Since(start), time.Duration(timeSettings.Timeout)*time.Second)
+		span.SetAttribute(AttrTaskPolls, count)
+		span.SetAttribute(AttrTaskStatus, last.Status)
+		span.SetAttribute(AttrTaskCommand, command)
+		span.End(err)
+	}()
+
+	c.logger.Info("Waiting for task to finish", "task_id", taskID)
 	stateConf := &StateChangeConf{
 		Pending:    []string{"WAIT", "PROCESSING"},
 		Target:     []string{"DONE"},
-		Refresh:    c.taskStateRefreshfunc(taskID),
+		Refresh:    c.taskStateRefreshfunc(ctx, taskID, polls),
 		Timeout:    time.Duration(timeSettings.Timeout) * time.Second,
 		Delay:      time.Duration(timeSettings.Delay) * time.Second,
 		MinTimeout: time.Duration(timeSettings.Interval) * time.Second,
+		Logger:     c.logger,
+	}
+	if c.pollScale != 1 {
+		// poll at a fixed, scaled interval instead of the backoff, see WithPollScale
+		stateConf.Delay = time.Duration(float64(stateConf.Delay) * c.pollScale)
+		stateConf.PollInterval = time.Duration(float64(stateConf.MinTimeout) * c.pollScale)
+		if stateConf.PollInterval < time.Millisecond {
+			stateConf.PollInterval = time.Millisecond
+		}
+		stateConf.MinTimeout = stateConf.PollInterval
 	}
-	res, err := stateConf.WaitForState()
+	events := c.newTaskEvents(ctx, taskID)
+	events.attach(stateConf)
+	res, err := stateConf.WaitForStateContext(ctx)
+	events.close()
 	if err != nil {
+		if ctx.Err() != nil {
+			return TaskStatus{}, ctx.Err()
+		}
+		if _, last := polls.get(); last.Status == "ERROR" {
+			return last, err
+		}
 		return TaskStatus{}, err
 	}
 	return res.(TaskStatus), err
 }
 
-func (c *Client) taskStateRefreshfunc(taskID string) StateRefreshFunc {
+func (c *Client) taskStateRefreshfunc(ctx context.Context, taskID string, polls *taskPolls) StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		// Get task result from cloud server API
-		resp, err := c.Task.Get(taskID)
+		resp, err := c.Task.GetWithContext(ctx, taskID)
 		if err != nil {
 			return nil, "", err
 		}
+		polls.add(resp)
 		// if the task is not ready, we need to wait for a moment
 		if resp.Status == "ERROR" {
-			log.Println("[DEBUG] Task is failed")
-			return nil, "", errors.New(fmt.Sprint(resp))
+			c.logger.Debug("Task is failed", "task_id", taskID, "error_text", resp.ErrorText)
+			return resp, resp.Status, errors.New(fmt.Sprint(resp))
 		}
 
 		if resp.Status == "DONE" {
 			return resp, "DONE", nil
 		}
 
-		log.Println("[DEBUG] Task is not done")
+		c.logger.Debug("Task is not done", "task_id", taskID, "status", resp.Status)
+		// a pending task must return its status, a nil result counts as not
+		// found and fails the wait after NotFoundChecks polls
+		if resp.Status == "WAIT" || resp.Status == "PROCESSING" {
+			return resp, resp.Status, nil
+		}
 		return nil, "", nil
 	}
 }
+
+// startCallSpan starts the span of a long running call
+func (c *Client) startCallSpan(ctx context.Context, action, id string) (context.Context, Span) {
+	ctx, span := c.tracer.Start(ctx, action)
+	span.SetAttribute(AttrEndpoint, action)
+	if id != "" {
+		span.SetAttribute(AttrResourceID, id)
+	}
+	return ctx, span
+}
+
+// resourceID returns the id param of a request, if any
+func resourceID(params map[string]string, body map[string]interface{}) string {
+	if id := params["id"]; id != "" {
+		return id
+	}
+	if id, ok := body["id"].(string); ok {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package gocmcapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorCodeInBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"task status with error text", `{"status":"ERROR","error_text":"disk full"}`, nil},
+		{"words in a resource name", `{"name":"error_code and error_text"}`, nil},
+		{"zero error code", `{"error_code":0,"error_text":""}`, nil},
+		{"null error code", `{"error_code":null,"error_text":"x"}`, nil},
+		{"list", `[{"error_code":404,"error_text":"x"}]`, nil},
+		{"not found", `{"error_code":404,"error_text":"Server not found"}`, ErrNotFound},
+		{"string code", `{"error_code":"403","error_text":"denied"}`, ErrPermissionDenied},
+		{"other code", `{"error_code":1,"error_text":"failed"}`, ErrCommon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+			c, err := NewClient("key", WithBaseURL(ts.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := c.Get("job/status", nil)
+			if body != tt.body {
+				t.Errorf("body = %s, want %s", body, tt.body)
+			}
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want an APIError matching %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package gocmcapi
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// ConnPoolSettings controls the idle connections kept open to the api server
+type ConnPoolSettings struct {
+	MaxIdleConns        int           // Max idle connections in total, 0 means no limit
+	MaxIdleConnsPerHost int           // Max idle connections to the api host
+	IdleConnTimeout     time.Duration // Close idle connections after this time, 0 means never
+}
+
+// DefaultConnPoolSettings is used when no pool option is given, it keeps enough
+// connections for many servers being provisioned in parallel
+var DefaultConnPoolSettings = ConnPoolSettings{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 100,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+func (s ConnPoolSettings) newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          s.MaxIdleConns,
+		MaxIdleConnsPerHost:   s.MaxIdleConnsPerHost,
+		IdleConnTimeout:       s.IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
@@ -0,0 +1,140 @@
+package gocmcapi_test
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+// newTLSCloud serves cloud over TLS, *handshakes counts the TLS handshakes
+func newTLSCloud(cloud *fakecloud.Cloud, handshakes *int64) *httptest.Server {
+	ts := httptest.NewUnstartedServer(cloud)
+	ts.TLS = &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			atomic.AddInt64(handshakes, 1)
+			return nil, nil
+		},
+	}
+	ts.StartTLS()
+	return ts
+}
+
+// pooledTransport is the transport of a client with the default pool, trusting ts
+func pooledTransport(ts *httptest.Server) http.RoundTripper {
+	transport := gocmcapi.DefaultConnPoolSettings.NewTransport()
+	transport.TLSClientConfig = ts.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	return transport
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// freshTransport sends every request on a new transport, so no connection is reused
+func freshTransport(ts *httptest.Server) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		transport := pooledTransport(ts).(*http.Transport)
+		transport.DisableKeepAlives = true
+		return transport.RoundTrip(r)
+	})
+}
+
+func newTLSClient(tb testing.TB, ts *httptest.Server, transport http.RoundTripper) *gocmcapi.Client {
+	c, err := gocmcapi.NewClient("key", gocmcapi.WithBaseURL(ts.URL+fakecloud.BasePath),
+		gocmcapi.WithPollScale(0), gocmcapi.WithTransport(transport))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return c
+}
+
+// createServers creates n servers in parallel
+func createServers(c *gocmcapi.Client, n int) error {
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, err := c.Server.Create(map[string]interface{}{"name": fmt.Sprintf("web-%d", i)})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestConnectionReuse(t *testing.T) {
+	var handshakes int64
+	cloud := fakecloud.New()
+	ts := newTLSCloud(cloud, &handshakes)
+	defer ts.Close()
+	c := newTLSClient(t, ts, pooledTransport(ts))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+	for i := 0; i < 20; i++ {
+		if _, err := c.Server.Get(s.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt64(&handshakes); n != 1 {
+		t.Errorf("20 sequential requests made %d TLS handshakes, want 1", n)
+	}
+}
+
+func TestParallelCreateReusesConnections(t *testing.T) {
+	var pooled, fresh int64
+	for _, tt := range []struct {
+		handshakes *int64
+		transport  func(*httptest.Server) http.RoundTripper
+	}{{&pooled, pooledTransport}, {&fresh, freshTransport}} {
+		cloud := fakecloud.New()
+		ts := newTLSCloud(cloud, tt.handshakes)
+		if err := createServers(newTLSClient(t, ts, tt.transport(ts)), 100); err != nil {
+			t.Fatal(err)
+		}
+		ts.Close()
+	}
+	// every create sends the order and its polls, only the pool reuses their connections
+	if pooled*2 > fresh {
+		t.Errorf("100 parallel creates made %d TLS handshakes with the pool, %d without", pooled, fresh)
+	}
+}
+
+// BenchmarkConnectionReuse provisions 100 servers in parallel per op, with the
+// default pool and with a new transport per request
+func BenchmarkConnectionReuse(b *testing.B) {
+	for _, bb := range []struct {
+		name      string
+		transport func(*httptest.Server) http.RoundTripper
+	}{{"pooled", pooledTransport}, {"fresh", freshTransport}} {
+		b.Run(bb.name, func(b *testing.B) {
+			var handshakes int64
+			cloud := fakecloud.New()
+			ts := newTLSCloud(cloud, &handshakes)
+			defer ts.Close()
+			c := newTLSClient(b, ts, bb.transport(ts))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := createServers(c, 100); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(&handshakes))/float64(b.N), "handshakes/op")
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+func TestContextDeadlineStopsWait(t *testing.T) {
+	cloud := fakecloud.New(fakecloud.WithJobPolls(1 << 30))
+	c := newFakeClient(t, cloud)
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.Server.StopWithContext(ctx, s.ID)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("StopWithContext error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("StopWithContext returned after %v, want soon after the 50ms deadline", elapsed)
+	}
+	time.Sleep(20 * time.Millisecond)
+	polls := count(cloud, "job/status")
+	time.Sleep(20 * time.Millisecond)
+	if n := count(cloud, "job/status"); n != polls {
+		t.Errorf("still polling after the deadline: %d polls, then %d", polls, n)
+	}
+}
+
+func TestCancelledContextSendsNothing(t *testing.T) {
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud)
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Server.StopWithContext(ctx, s.ID); !errors.Is(err, context.Canceled) {
+		t.Errorf("StopWithContext error = %v, want context.Canceled", err)
+	}
+	if n := count(cloud, "server_action/stop"); n != 0 {
+		t.Errorf("%d stop requests sent with a cancelled context", n)
+	}
+}
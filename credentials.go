@@ -0,0 +1,242 @@
+package gocmcapi
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by LoadCredentials
+const (
+	EnvAPIKey     = "CMC_API_KEY"     // Api key
+	EnvAPIURL     = "CMC_API_URL"     // Api url, used with CMC_API_KEY
+	EnvProfile    = "CMC_PROFILE"     // Profile of the config file, "default" if empty
+	EnvConfigFile = "CMC_CONFIG_FILE" // Config file, ~/.cmc/config if empty
+)
+
+// DefaultProfile is the profile used when none is given
+const DefaultProfile = "default"
+
+// Credentials to access the api
+type Credentials struct {
+	APIKey string
+	APIURL string // Empty for the default api url
+	Source string // Where the credentials were found
+
+	// TimeSettings by endpoint, from the timeout.<endpoint>,
+	// interval.<endpoint> and delay.<endpoint> keys of a profile
+	TimeSettings map[string]TimeSettings
+}
+
+// CredentialsError is returned when no credentials are found, it names every
+// source that was checked
+type CredentialsError struct {
+	Checked []string
+}
+
+func (e *CredentialsError) Error() string {
+	return "no CMC Cloud api key found, checked: " + strings.Join(e.Checked, "; ")
+}
+
+// DefaultConfigFile returns the config file path, $CMC_CONFIG_FILE or ~/.cmc/config
+func DefaultConfigFile() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cmc", "config"), nil
+}
+
+// LoadCredentials finds the api credentials. With an empty profile they are
+// taken from, in order:
+//
+//  1. the CMC_API_KEY and CMC_API_URL environment variables
+//  2. the profile named by CMC_PROFILE, or "default", in the config file
+//
+// A non empty profile is only looked up in the config file. The config file is
+// $CMC_CONFIG_FILE or ~/.cmc/config, an INI file with a section per profile:
+//
+//	[default]
+//	api_key = ...
+//
+//	[staging]
+//	api_key = ...
+//	api_url = https://staging.example.com/ver2
+//	timeout.server_action/resize = 1h
+//
+// timeout, interval and delay keys override the TimeSettings of an endpoint,
+// in whole seconds or as a duration such as 90m
+func LoadCredentials(profile string) (Credentials, error) {
+	var checked []string
+	if profile == "" {
+		if key := os.Getenv(EnvAPIKey); key != "" {
+			return Credentials{APIKey: key, APIURL: os.Getenv(EnvAPIURL), Source: "environment variable " + EnvAPIKey}, nil
+		}
+		checked = append(checked, "environment variable "+EnvAPIKey+" (not set)")
+		profile = os.Getenv(EnvProfile)
+		if profile == "" {
+			profile = DefaultProfile
+		}
+	}
+
+	path, err := DefaultConfigFile()
+	if err != nil {
+		checked = append(checked, fmt.Sprintf("config file (%s)", err))
+		return Credentials{}, &CredentialsError{Checked: checked}
+	}
+	creds, err := LoadProfile(path, profile)
+	if err != nil {
+		var credsErr *CredentialsError
+		if errors.As(err, &credsErr) {
+			credsErr.Checked = append(checked, credsErr.Checked...)
+		}
+		return Credentials{}, err
+	}
+	return creds, nil
+}
+
+// LoadProfile reads the credentials of a profile from an INI config file
+func LoadProfile(path, profile string) (Credentials, error) {
+	source := fmt.Sprintf("profile %q in %s", profile, path)
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Credentials{}, &CredentialsError{Checked: []string{source + " (file not found)"}}
+	}
+	if err != nil {
+		return Credentials{}, fmt.Errorf("Error reading config file %s: %w", path, err)
+	}
+	profiles, err := parseINI(data)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("Error parsing config file %s: %w", path, err)
+	}
+	values, ok := profiles[profile]
+	if !ok {
+		return Credentials{}, &CredentialsError{Checked: []string{source + " (profile not found)"}}
+	}
+	if values["api_key"] == "" {
+		return Credentials{}, &CredentialsError{Checked: []string{source + " (no api_key)"}}
+	}
+	creds := Credentials{APIKey: values["api_key"], APIURL: values["api_url"], Source: source}
+	creds.TimeSettings, err = parseTimeSettings(values)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("Error parsing config file %s: %w", path, err)
+	}
+	return creds, nil
+}
+
+// parseTimeSettings returns the TimeSettings of the timeout.<endpoint>,
+// interval.<endpoint> and delay.<endpoint> keys of a profile
+func parseTimeSettings(values map[string]string) (map[string]TimeSettings, error) {
+	var settings map[string]TimeSettings
+	for key, value := range values {
+		i := strings.IndexByte(key, '.')
+		if i < 0 {
+			continue
+		}
+		field, endpoint := key[:i], key[i+1:]
+		if field != "timeout" && field != "interval" && field != "delay" {
+			continue
+		}
+		seconds, err := parseSeconds(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid duration %q", key, value)
+		}
+		if settings == nil {
+			settings = make(map[string]TimeSettings)
+		}
+		ts := settings[endpoint]
+		switch field {
+		case "timeout":
+			ts.Timeout = seconds
+		case "interval":
+			ts.Interval = seconds
+		case "delay":
+			ts.Delay = seconds
+		}
+		settings[endpoint] = ts
+	}
+	return settings, nil
+}
+
+// parseSeconds parses a number of seconds or a duration such as 90m, it must
+// be a whole number of seconds and not negative
+func parseSeconds(value string) (int, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, errors.New("negative duration")
+		}
+		return seconds, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 || d%time.Second != 0 {
+		return 0, errors.New("not a whole number of seconds")
+	}
+	return int(d / time.Second), nil
+}
+
+// parseINI returns the key/values of each section, keys outside a section
+// belong to the "default" section
+func parseINI(data []byte) (map[string]map[string]string, error) {
+	sections := make(map[string]map[string]string)
+	section := DefaultProfile
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sections[section]; !ok {
+				sections[section] = make(map[string]string)
+			}
+			continue
+		}
+		i := strings.IndexByte(line, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		if _, ok := sections[section]; !ok {
+			sections[section] = make(map[string]string)
+		}
+		value := strings.TrimSpace(line[i+1:])
+		value = strings.Trim(value, `"'`)
+		sections[section][strings.TrimSpace(line[:i])] = value
+	}
+	return sections, scanner.Err()
+}
+
+// NewClientFromEnvironment creates a client with the credentials found by
+// LoadCredentials(""), opts are applied after the credentials
+func NewClientFromEnvironment(opts ...ClientOption) (*Client, error) {
+	return NewClientFromProfile("", opts...)
+}
+
+// NewClientFromProfile creates a client with the credentials of a profile of
+// the config file, an empty profile behaves as NewClientFromEnvironment
+func NewClientFromProfile(profile string, opts ...ClientOption) (*Client, error) {
+	creds, err := LoadCredentials(profile)
+	if err != nil {
+		return nil, err
+	}
+	if creds.APIURL != "" {
+		opts = append([]ClientOption{WithBaseURL(creds.APIURL)}, opts...)
+	}
+	if len(creds.TimeSettings) > 0 {
+		opts = append([]ClientOption{WithEndpointTimeSettings(creds.TimeSettings)}, opts...)
+	}
+	return NewClient(creds.APIKey, opts...)
+}
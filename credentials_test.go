@@ -0,0 +1,153 @@
+package gocmcapi_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+)
+
+const testConfig = `
+[default]
+api_key = default-key
+
+[staging]
+api_key = "staging-key"
+api_url = https://staging.example.com/ver2
+timeout.server_action/resize = 1h
+interval.server_action/resize = 30
+delay.snapshot/create = 90s
+
+[empty]
+api_url = https://example.com
+`
+
+// setenv sets an environment variable for the duration of the test
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// writeConfig writes a config file and points CMC_CONFIG_FILE to it
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	setenv(t, gocmcapi.EnvConfigFile, path)
+	return path
+}
+
+func TestLoadCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		profile string
+		want    gocmcapi.Credentials
+	}{
+		{
+			name: "environment over profile",
+			env:  map[string]string{gocmcapi.EnvAPIKey: "env-key", gocmcapi.EnvAPIURL: "https://env.example.com", gocmcapi.EnvProfile: "staging"},
+			want: gocmcapi.Credentials{APIKey: "env-key", APIURL: "https://env.example.com", Source: "environment variable CMC_API_KEY"},
+		},
+		{
+			name: "default profile",
+			want: gocmcapi.Credentials{APIKey: "default-key", Source: `profile "default" in CONFIG`},
+		},
+		{
+			name: "CMC_PROFILE",
+			env:  map[string]string{gocmcapi.EnvProfile: "staging"},
+			want: gocmcapi.Credentials{
+				APIKey: "staging-key",
+				APIURL: "https://staging.example.com/ver2",
+				Source: `profile "staging" in CONFIG`,
+				TimeSettings: map[string]gocmcapi.TimeSettings{
+					"server_action/resize": {Timeout: 3600, Interval: 30},
+					"snapshot/create":      {Delay: 90},
+				},
+			},
+		},
+		{
+			name:    "explicit profile ignores the environment",
+			env:     map[string]string{gocmcapi.EnvAPIKey: "env-key", gocmcapi.EnvProfile: "staging"},
+			profile: "default",
+			want:    gocmcapi.Credentials{APIKey: "default-key", Source: `profile "default" in CONFIG`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, testConfig)
+			for _, key := range []string{gocmcapi.EnvAPIKey, gocmcapi.EnvAPIURL, gocmcapi.EnvProfile} {
+				setenv(t, key, tt.env[key])
+			}
+			got, err := gocmcapi.LoadCredentials(tt.profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.want.Source = strings.Replace(tt.want.Source, "CONFIG", path, 1)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadCredentials(%q) = %+v, want %+v", tt.profile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCredentialsErrorChecked(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string // "" for no file
+		envProfile string
+		profile    string
+		want       []string
+	}{
+		{"no file", "", "", "", []string{"environment variable CMC_API_KEY (not set)", `profile "default" in CONFIG (file not found)`}},
+		{"no profile", testConfig, "prod", "", []string{"environment variable CMC_API_KEY (not set)", `profile "prod" in CONFIG (profile not found)`}},
+		{"no api key", testConfig, "prod", "empty", []string{`profile "empty" in CONFIG (no api_key)`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.config)
+			if tt.config == "" {
+				os.Remove(path)
+			}
+			setenv(t, gocmcapi.EnvAPIKey, "")
+			setenv(t, gocmcapi.EnvProfile, tt.envProfile)
+
+			_, err := gocmcapi.LoadCredentials(tt.profile)
+			var credsErr *gocmcapi.CredentialsError
+			if !errors.As(err, &credsErr) {
+				t.Fatalf("error = %v, want a CredentialsError", err)
+			}
+			for i := range tt.want {
+				tt.want[i] = strings.Replace(tt.want[i], "CONFIG", path, 1)
+			}
+			if !reflect.DeepEqual(credsErr.Checked, tt.want) {
+				t.Errorf("Checked = %q, want %q", credsErr.Checked, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidTimeSettings(t *testing.T) {
+	for _, value := range []string{"500ms", "1500ms", "-5", "-1m", "soon"} {
+		writeConfig(t, "[default]\napi_key = k\ntimeout.server/create = "+value+"\n")
+		_, err := gocmcapi.LoadProfile(os.Getenv(gocmcapi.EnvConfigFile), "default")
+		if err == nil || !strings.Contains(err.Error(), `timeout.server/create: invalid duration "`+value+`"`) {
+			t.Errorf("%s: error = %v, want invalid duration", value, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package gocmcapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DryRunTaskID is the task id of the synthetic responses returned in dry-run mode
+const DryRunTaskID = "dry-run"
+
+// dryRunResponse is the body returned instead of executing a mutating request
+const dryRunResponse = `{"success":true,"jobid":"` + DryRunTaskID + `","paid":true,"price":0}`
+
+// Operation is a mutating request recorded, and not executed, in dry-run mode
+type Operation struct {
+	Method string                 // Http method
+	Path   string                 // Api path, e.g. server_action/resize
+	Params map[string]interface{} // Query params and body, secrets redacted
+	Time   time.Time
+}
+
+func (o Operation) String() string {
+	return fmt.Sprintf("%s %s %v", o.Method, o.Path, o.Params)
+}
+
+// dryRunPlan holds the recorded operations, it is a pointer so clients
+// returned by ForRegion share the plan
+type dryRunPlan struct {
+	mu         sync.Mutex
+	operations []Operation
+}
+
+func (p *dryRunPlan) add(op Operation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.operations = append(p.operations, op)
+}
+
+// Plan returns the operations recorded in dry-run mode, see WithDryRun
+func (c *Client) Plan() []Operation {
+	if c.plan == nil {
+		return nil
+	}
+	c.plan.mu.Lock()
+	defer c.plan.mu.Unlock()
+	return append([]Operation(nil), c.plan.operations...)
+}
+
+// ResetPlan clears the operations recorded in dry-run mode
+func (c *Client) ResetPlan() {
+	if c.plan == nil {
+		return
+	}
+	c.plan.mu.Lock()
+	defer c.plan.mu.Unlock()
+	c.plan.operations = nil
+}
+
+// recordDryRun records a mutating request instead of executing it, the body must
+// still be encodable
+func (c *Client) recordDryRun(method, path string, params map[string]string, body map[string]interface{}) (string, error) {
+	if _, err := json.Marshal(body); err != nil {
+		return "", fmt.Errorf("Error encode params of %s %s: %w", method, path, err)
+	}
+	merged := make(map[string]interface{}, len(params)+len(body))
+	for key, value := range params {
+		merged[key] = value
+	}
+	for key, value := range body {
+		merged[key] = value
+	}
+	op := Operation{Method: method, Path: path, Params: redactParams(merged), Time: time.Now()}
+	c.plan.add(op)
+	c.logger.Info("Dry run, request not sent", "method", method, "path", path, "params", op.Params)
+	return dryRunResponse, nil
+}
+
+// readOnlyCtx marks the requests of a context as changing nothing, whatever
+// their method, see withReadOnly
+type readOnlyCtx struct{}
+
+// withReadOnly returns a context for a POST which only reads, e.g. a
+// validation, so it is sent in dry-run mode and invalidates no cache
+func withReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyCtx{}, true)
+}
+
+// isMutating reports whether a request of method is skipped in dry-run mode
+func isMutating(ctx context.Context, method string) bool {
+	if readOnly, _ := ctx.Value(readOnlyCtx{}).(bool); readOnly {
+		return false
+	}
+	return method != http.MethodGet && method != http.MethodHead
+}
+
+// dryRunTaskStatus is the TaskStatus returned by long tasks in dry-run mode
+func dryRunTaskStatus(action, id string) TaskStatus {
+	return TaskStatus{Command: action, Status: "DONE", ResultID: id}
+}
@@ -0,0 +1,65 @@
+package gocmcapi_test
+
+import (
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+func TestDryRunDeleteAllRules(t *testing.T) {
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud)
+	_, status, err := c.VPC.Create("net", "", "hn", "10.0.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err = c.FirewallVPC.Create(status.ResultID, "fw", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	firewallID := status.ResultID
+	for i := 1; i <= 2; i++ {
+		if _, err := c.FirewallVPC.CreateRule(firewallID, i, "0.0.0.0/0", "allow", "tcp", "inbound", "22"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dry := newFakeClient(t, cloud, gocmcapi.WithDryRun(true))
+	before := len(cloud.Requests())
+	if err := dry.FirewallVPC.DeleteAllRules(firewallID); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range cloud.Requests()[before:] {
+		if path != "firewall_vpc/get_rules" {
+			t.Errorf("dry run sent %s", path)
+		}
+	}
+	if plan := dry.Plan(); len(plan) != 2 || plan[0].Path != "firewall_vpc/delete_rule" {
+		t.Errorf("plan = %v, want 2 firewall_vpc/delete_rule operations", plan)
+	}
+	rules, err := c.FirewallVPC.GetRules(firewallID)
+	if err != nil || len(rules) != 2 {
+		t.Errorf("rules after dry run = %v, %v", rules, err)
+	}
+}
+
+func TestDryRunValidateRules(t *testing.T) {
+	cloud := fakecloud.New()
+	dry := newFakeClient(t, cloud, gocmcapi.WithDryRun(true))
+
+	valid := `[{"action":"allow","protocol":"tcp","port":"22","cidr":"0.0.0.0/0"}]`
+	errs, err := dry.FirewallVPC.ValidateRules(valid, "not json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 {
+		t.Errorf("ValidateRules = %q, want the error of the outbound rules", errs)
+	}
+	if plan := dry.Plan(); len(plan) != 0 {
+		t.Errorf("plan = %v, want no operation for a validation", plan)
+	}
+	if n := count(cloud, "firewall_vpc/validate_rules"); n != 1 {
+		t.Errorf("%d validate_rules requests sent, want 1", n)
+	}
+}
@@ -0,0 +1,20 @@
+package gocmcapi
+
+import "net/http"
+
+// SubmissionCount returns the number of submissions kept by idempotency key
+func (c *Client) SubmissionCount() int {
+	c.submissions.mu.Lock()
+	defer c.submissions.mu.Unlock()
+	return len(c.submissions.byKey)
+}
+
+// TimeSettings returns the TimeSettings the wait of h uses
+func (h *TaskHandle) TimeSettings() TimeSettings {
+	return h.timeSettings
+}
+
+// NewTransport returns the transport a client builds from s
+func (s ConnPoolSettings) NewTransport() *http.Transport {
+	return s.newTransport()
+}
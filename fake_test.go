@@ -0,0 +1,23 @@
+package gocmcapi_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+// newFakeClient returns a client of a fakecloud.Cloud which polls tasks
+// without waiting
+func newFakeClient(t *testing.T, cloud *fakecloud.Cloud, opts ...gocmcapi.ClientOption) *gocmcapi.Client {
+	t.Helper()
+	ts := httptest.NewServer(cloud)
+	t.Cleanup(ts.Close)
+	opts = append([]gocmcapi.ClientOption{gocmcapi.WithBaseURL(ts.URL + fakecloud.BasePath), gocmcapi.WithPollScale(0)}, opts...)
+	c, err := gocmcapi.NewClient("key", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -0,0 +1,479 @@
+// Package fakecloud is an in-memory fake of the CMC Cloud api, it serves the
+// same /ver2/*.json endpoints as the real api so code using gocmcapi can run
+// against an httptest.Server in CI:
+//
+//	cloud := fakecloud.New()
+//	ts := httptest.NewServer(cloud)
+//	defer ts.Close()
+//	client, err := gocmcapi.NewClient("key", gocmcapi.WithBaseURL(ts.URL+fakecloud.BasePath), gocmcapi.WithPollScale(0))
+//
+// Long running actions start jobs that go WAIT -> PROCESSING -> DONE as
+// job/status is polled, the change is applied to the model when the job is
+// DONE. Faults such as unpaid orders, failed jobs, 5xx responses and latency
+// can be injected with InjectFault.
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+)
+
+// BasePath is the path prefix of the api, append it to the test server url
+const BasePath = "/ver2"
+
+// Task statuses reported by job/status
+const (
+	StatusWait       = "WAIT"
+	StatusProcessing = "PROCESSING"
+	StatusDone       = "DONE"
+	StatusError      = "ERROR"
+)
+
+// Fault changes the answer to the requests matching Path
+type Fault struct {
+	Path       string        // Api path, e.g. server/create, "server_action/*" matches a prefix, "" matches all
+	StatusCode int           // Answer with this http status and an api error instead of handling the request
+	RetryAfter time.Duration // Retry-After header sent with StatusCode, rounded up to seconds
+	Unpaid     bool          // Orders are answered as not paid and no job is started
+	TaskError  string        // Started jobs finish with ERROR and this error text
+	Latency    time.Duration // Wait this long before answering
+	Drop       bool          // Handle the request but close the connection without answering
+	Times      int           // Number of requests affected, 0 means every request
+}
+
+func (f *Fault) matches(path string) bool {
+	if f.Path == "" || f.Path == path {
+		return true
+	}
+	return strings.HasSuffix(f.Path, "*") && strings.HasPrefix(path, strings.TrimSuffix(f.Path, "*"))
+}
+
+// Option configures a Cloud
+type Option func(*Cloud)
+
+// WithAPIKey makes the cloud reject requests without this api key
+func WithAPIKey(apiKey string) Option {
+	return func(c *Cloud) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithJobPolls sets how many job/status polls a job stays pending before it
+// finishes, the first poll reports WAIT and the others PROCESSING. Default is 2
+func WithJobPolls(polls int) Option {
+	return func(c *Cloud) {
+		c.jobPolls = polls
+	}
+}
+
+// WithRegions sets the regions returned by region/list, a region without
+// APIURL is listed with the url of the cloud itself
+func WithRegions(regions []gocmcapi.Region) Option {
+	return func(c *Cloud) {
+		c.regions = regions
+	}
+}
+
+// WithPrice sets the price returned for every order
+func WithPrice(price int) Option {
+	return func(c *Cloud) {
+		c.price = price
+	}
+}
+
+// WithIdempotency replays the answer of a mutating request whose
+// Idempotency-Key header was seen before instead of handling it again
+func WithIdempotency() Option {
+	return func(c *Cloud) {
+		c.idempotent = true
+	}
+}
+
+type job struct {
+	id        string
+	command   string
+	resultID  string
+	errorText string
+	polls     int
+	status    string
+	apply     func()
+}
+
+type handlerFunc func(req *request) (interface{}, error)
+
+// Cloud is an in-memory CMC Cloud api, it implements http.Handler
+type Cloud struct {
+	apiKey     string
+	jobPolls   int
+	price      int
+	regions    []gocmcapi.Region
+	idempotent bool
+
+	routes map[string]handlerFunc
+
+	mu              sync.Mutex
+	seq             int
+	faults          []*Fault
+	requests        []string
+	answers         map[string][]byte
+	jobs            map[string]*job
+	servers         map[string]*gocmcapi.Server
+	volumes         map[string]*gocmcapi.Volume
+	snapshots       map[string]*gocmcapi.Snapshot
+	vpcs            map[string]*gocmcapi.VPC
+	networks        map[string]*gocmcapi.Network
+	floatingIPs     map[string]*gocmcapi.FloatingIP
+	firewallVPCs    map[string]*gocmcapi.FirewallVPC
+	firewallDirects map[string]*gocmcapi.FirewallDirect
+}
+
+// New creates an empty Cloud
+func New(opts ...Option) *Cloud {
+	c := &Cloud{
+		jobPolls:        2,
+		regions:         DefaultRegions,
+		jobs:            make(map[string]*job),
+		answers:         make(map[string][]byte),
+		servers:         make(map[string]*gocmcapi.Server),
+		volumes:         make(map[string]*gocmcapi.Volume),
+		snapshots:       make(map[string]*gocmcapi.Snapshot),
+		vpcs:            make(map[string]*gocmcapi.VPC),
+		networks:        make(map[string]*gocmcapi.Network),
+		floatingIPs:     make(map[string]*gocmcapi.FloatingIP),
+		firewallVPCs:    make(map[string]*gocmcapi.FirewallVPC),
+		firewallDirects: make(map[string]*gocmcapi.FirewallDirect),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.routes = map[string]handlerFunc{
+		"job/status":  c.jobStatus,
+		"region/list": c.regionList,
+	}
+	c.serverRoutes()
+	c.storageRoutes()
+	c.networkRoutes()
+	return c
+}
+
+// DefaultRegions are the regions of a Cloud created without WithRegions
+var DefaultRegions = []gocmcapi.Region{
+	{ID: "hn", Name: "Ha Noi"},
+	{ID: "hcm", Name: "Ho Chi Minh"},
+}
+
+func (c *Cloud) regionList(req *request) (interface{}, error) {
+	regions := append([]gocmcapi.Region(nil), c.regions...)
+	for i := range regions {
+		if regions[i].APIURL == "" {
+			regions[i].APIURL = req.baseURL
+		}
+	}
+	return regions, nil
+}
+
+// region returns the id and name of a region given by id or name, an
+// unknown region is used as both
+func (c *Cloud) region(value string) (id, name string) {
+	for _, r := range c.regions {
+		if r.ID == value || r.Name == value {
+			return r.ID, r.Name
+		}
+	}
+	return value, value
+}
+
+// InjectFault adds a fault, faults are applied in the order they are added
+func (c *Cloud) InjectFault(f Fault) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults = append(c.faults, &f)
+}
+
+// ClearFaults removes every injected fault
+func (c *Cloud) ClearFaults() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults = nil
+}
+
+// Requests returns the paths of the requests served so far, e.g. "server/create"
+func (c *Cloud) Requests() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.requests...)
+}
+
+// Task returns the current status of a job, without counting it as a poll
+func (c *Cloud) Task(id string) (gocmcapi.TaskStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	j, ok := c.jobs[id]
+	if !ok {
+		return gocmcapi.TaskStatus{}, false
+	}
+	return j.taskStatus(), true
+}
+
+// apiError is answered as {"success": false, "error_code": ..., "error_text": ...}
+type apiError struct {
+	status int
+	text   string
+}
+
+func (e *apiError) Error() string {
+	return e.text
+}
+
+func notFound(kind, id string) error {
+	return &apiError{status: http.StatusNotFound, text: fmt.Sprintf("%s %s not found", kind, id)}
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusBadRequest, text: fmt.Sprintf(format, args...)}
+}
+
+// request is a parsed api request, params hold both query and body params
+type request struct {
+	method  string
+	path    string
+	baseURL string // Url of the cloud, e.g. http://127.0.0.1:1234/ver2
+	params  map[string]interface{}
+	faults  []Fault
+}
+
+func (r *request) str(key string) string {
+	switch v := r.params[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (r *request) int(key string) int {
+	switch v := r.params[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
+
+func (r *request) has(key string) bool {
+	_, ok := r.params[key]
+	return ok
+}
+
+// ServeHTTP implements http.Handler
+func (c *Cloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, BasePath+"/")
+	path = strings.TrimSuffix(path, ".json")
+
+	req := &request{method: r.Method, path: path, params: make(map[string]interface{})}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	req.baseURL = scheme + "://" + r.Host + BasePath
+	for key := range r.URL.Query() {
+		req.params[key] = r.URL.Query().Get(key)
+	}
+	if r.Body != nil && (r.Method == http.MethodPost || r.Method == http.MethodPut) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err == nil {
+			for key, value := range body {
+				req.params[key] = value
+			}
+		}
+	}
+
+	if c.apiKey != "" && req.str("api_key") != c.apiKey && r.Header.Get("Authorization") != "Bearer "+c.apiKey {
+		writeError(w, &apiError{status: http.StatusUnauthorized, text: "invalid api key"})
+		return
+	}
+
+	req.faults = c.takeFaults(path)
+	for _, f := range req.faults {
+		if f.Latency > 0 {
+			select {
+			case <-time.After(f.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+	for _, f := range req.faults {
+		if f.StatusCode != 0 {
+			if f.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int((f.RetryAfter+time.Second-1)/time.Second)))
+			}
+			writeError(w, &apiError{status: f.StatusCode, text: http.StatusText(f.StatusCode)})
+			return
+		}
+	}
+
+	handler, ok := c.routes[path]
+	if !ok {
+		writeError(w, &apiError{status: http.StatusNotFound, text: "unknown endpoint " + path})
+		return
+	}
+
+	// encode while holding the lock, res may point into the model
+	c.mu.Lock()
+	c.requests = append(c.requests, path)
+	key := r.Header.Get(gocmcapi.IdempotencyKeyHeader)
+	if r.Method == http.MethodGet || !c.idempotent {
+		key = ""
+	}
+	body, replay := c.answers[path+" "+key]
+	var err error
+	if key == "" || !replay {
+		var res interface{}
+		res, err = handler(req)
+		if err == nil {
+			body, err = json.Marshal(res)
+		}
+		if err == nil && key != "" {
+			c.answers[path+" "+key] = body
+		}
+	}
+	c.mu.Unlock()
+
+	for _, f := range req.faults {
+		if f.Drop {
+			panic(http.ErrAbortHandler)
+		}
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// takeFaults returns the faults matching path and uses up their Times
+func (c *Cloud) takeFaults(path string) []Fault {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var matched []Fault
+	kept := c.faults[:0]
+	for _, f := range c.faults {
+		if f.matches(path) {
+			matched = append(matched, *f)
+			if f.Times > 0 {
+				f.Times--
+				if f.Times == 0 {
+					continue
+				}
+			}
+		}
+		kept = append(kept, f)
+	}
+	c.faults = kept
+	return matched
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		e = &apiError{status: http.StatusInternalServerError, text: err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    false,
+		"error_code": e.status,
+		"error_text": e.text,
+	})
+}
+
+// newID returns a new uuid-like id, c.mu must be held
+func (c *Cloud) newID() string {
+	c.seq++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", c.seq)
+}
+
+// startJob starts a job for req, apply is called when the job is DONE.
+// c.mu must be held
+func (c *Cloud) startJob(req *request, resultID string, apply func()) map[string]interface{} {
+	j := &job{
+		id:       c.newID(),
+		command:  req.path,
+		resultID: resultID,
+		status:   StatusWait,
+		apply:    apply,
+	}
+	for _, f := range req.faults {
+		if f.TaskError != "" {
+			j.errorText = f.TaskError
+		}
+	}
+	c.jobs[j.id] = j
+	return map[string]interface{}{"jobid": j.id}
+}
+
+// startOrder is startJob for paid actions, unless an Unpaid fault applies.
+// c.mu must be held
+func (c *Cloud) startOrder(req *request, resultID string, apply func()) map[string]interface{} {
+	for _, f := range req.faults {
+		if f.Unpaid {
+			return map[string]interface{}{"jobid": "", "price": c.price, "paid": false}
+		}
+	}
+	res := c.startJob(req, resultID, apply)
+	res["price"] = c.price
+	res["paid"] = true
+	return res
+}
+
+func (c *Cloud) jobStatus(req *request) (interface{}, error) {
+	j, ok := c.jobs[req.str("id")]
+	if !ok {
+		return nil, notFound("job", req.str("id"))
+	}
+	if j.status == StatusWait || j.status == StatusProcessing {
+		j.polls++
+		switch {
+		case j.polls > c.jobPolls:
+			j.finish()
+		case j.polls > 1:
+			j.status = StatusProcessing
+		}
+	}
+	return j.taskStatus(), nil
+}
+
+func (j *job) finish() {
+	if j.errorText != "" {
+		j.status = StatusError
+		return
+	}
+	if j.apply != nil {
+		j.apply()
+	}
+	j.status = StatusDone
+}
+
+func (j *job) taskStatus() gocmcapi.TaskStatus {
+	return gocmcapi.TaskStatus{
+		Command:   j.command,
+		Status:    j.status,
+		ResultID:  j.resultID,
+		ErrorText: j.errorText,
+	}
+}
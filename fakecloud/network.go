@@ -0,0 +1,482 @@
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cmc-cloud/gocmcapi"
+)
+
+func (c *Cloud) networkRoutes() {
+	c.routes["vpc/info"] = c.vpcInfo
+	c.routes["vpc/create"] = c.vpcCreate
+	c.routes["vpc/delete"] = c.vpcDelete
+	c.routes["vpc"] = c.vpcUpdate
+	c.routes["network/info"] = c.networkInfo
+	c.routes["network/create_vpc_network"] = c.networkCreateVPCNetwork
+	c.routes["network/update"] = c.networkUpdate
+	c.routes["network/change_firewall"] = c.networkChangeFirewall
+	c.routes["network/delete"] = c.networkDelete
+	c.routes["floatingip/info"] = c.floatingIPInfo
+	c.routes["floatingip/create"] = c.floatingIPCreate
+	c.routes["floatingip/delete"] = c.floatingIPDelete
+	c.routes["firewall_vpc/info"] = c.firewallVPCInfo
+	c.routes["firewall_vpc/create"] = c.firewallVPCCreate
+	c.routes["firewall_vpc/update"] = c.firewallVPCUpdate
+	c.routes["firewall_vpc/delete"] = c.firewallVPCDelete
+	c.routes["firewall_vpc/create_rule"] = c.firewallVPCCreateRule
+	c.routes["firewall_vpc/update_rule"] = c.firewallVPCUpdateRule
+	c.routes["firewall_vpc/delete_rule"] = c.firewallVPCDeleteRule
+	c.routes["firewall_vpc/get_rules"] = c.firewallVPCGetRules
+	c.routes["firewall_vpc/save_rules"] = c.firewallVPCSaveRules
+	c.routes["firewall_vpc/validate_rules"] = c.firewallVPCValidateRules
+	c.routes["firewall_direct/info"] = c.firewallDirectInfo
+	c.routes["firewall_direct/save_rules"] = c.firewallDirectSaveRules
+	c.routes["firewall_direct/delete"] = c.firewallDirectDelete
+}
+
+// VPC returns a vpc of the model
+func (c *Cloud) VPC(id string) (gocmcapi.VPC, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.vpcs[id]
+	if !ok {
+		return gocmcapi.VPC{}, false
+	}
+	return *v, true
+}
+
+// Network returns a network of the model
+func (c *Cloud) Network(id string) (gocmcapi.Network, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, ok := c.networks[id]
+	if !ok {
+		return gocmcapi.Network{}, false
+	}
+	return *n, true
+}
+
+// FloatingIP returns a floating ip of the model
+func (c *Cloud) FloatingIP(id string) (gocmcapi.FloatingIP, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, ok := c.floatingIPs[id]
+	if !ok {
+		return gocmcapi.FloatingIP{}, false
+	}
+	return *f, true
+}
+
+// FirewallVPC returns a vpc firewall of the model
+func (c *Cloud) FirewallVPC(id string) (gocmcapi.FirewallVPC, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, ok := c.firewallVPCs[id]
+	if !ok {
+		return gocmcapi.FirewallVPC{}, false
+	}
+	return *f, true
+}
+
+func (c *Cloud) vpc(req *request, key string) (*gocmcapi.VPC, error) {
+	v, ok := c.vpcs[req.str(key)]
+	if !ok {
+		return nil, notFound("vpc", req.str(key))
+	}
+	return v, nil
+}
+
+func (c *Cloud) vpcInfo(req *request) (interface{}, error) {
+	return c.vpc(req, "id")
+}
+
+func (c *Cloud) vpcCreate(req *request) (interface{}, error) {
+	if req.str("cidr") == "" {
+		return nil, badRequest("cidr is required")
+	}
+	v := &gocmcapi.VPC{
+		ID:          c.newID(),
+		Name:        req.str("name"),
+		State:       "Enabled",
+		Cidr:        req.str("cidr"),
+		Description: req.str("description"),
+	}
+	v.RegionID, v.RegionName = c.region(req.str("region"))
+	return c.startOrder(req, v.ID, func() { c.vpcs[v.ID] = v }), nil
+}
+
+func (c *Cloud) vpcUpdate(req *request) (interface{}, error) {
+	v, err := c.vpc(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	name, description := req.str("name"), req.str("description")
+	return c.startJob(req, v.ID, func() {
+		v.Name = name
+		v.Description = description
+	}), nil
+}
+
+func (c *Cloud) vpcDelete(req *request) (interface{}, error) {
+	v, err := c.vpc(req, "id")
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range c.networks {
+		if n.VPCID == v.ID {
+			return nil, badRequest("vpc %s still has network %s", v.ID, n.ID)
+		}
+	}
+	return c.startJob(req, v.ID, func() { delete(c.vpcs, v.ID) }), nil
+}
+
+func (c *Cloud) network(req *request) (*gocmcapi.Network, error) {
+	n, ok := c.networks[req.str("id")]
+	if !ok {
+		return nil, notFound("network", req.str("id"))
+	}
+	return n, nil
+}
+
+func (c *Cloud) networkInfo(req *request) (interface{}, error) {
+	return c.network(req)
+}
+
+func (c *Cloud) networkCreateVPCNetwork(req *request) (interface{}, error) {
+	v, err := c.vpc(req, "vpc_id")
+	if err != nil {
+		return nil, err
+	}
+	n := &gocmcapi.Network{
+		ID:          c.newID(),
+		Name:        req.str("name"),
+		Description: req.str("description"),
+		Gateway:     req.str("gateway"),
+		Netmask:     req.str("netmask"),
+		Cidr:        req.str("gateway") + "/" + req.str("netmask"),
+		State:       "Implemented",
+		Type:        "Isolated",
+		FirewallID:  req.str("firewall_id"),
+		VPCID:       v.ID,
+		ServerIDs:   []string{},
+	}
+	c.networks[n.ID] = n
+	return gocmcapi.ResultResponse{ResultID: n.ID}, nil
+}
+
+func (c *Cloud) networkUpdate(req *request) (interface{}, error) {
+	n, err := c.network(req)
+	if err != nil {
+		return nil, err
+	}
+	name, description := req.str("name"), req.str("description")
+	return c.startJob(req, n.ID, func() {
+		n.Name = name
+		n.Description = description
+	}), nil
+}
+
+func (c *Cloud) networkChangeFirewall(req *request) (interface{}, error) {
+	n, err := c.network(req)
+	if err != nil {
+		return nil, err
+	}
+	firewallID := req.str("firewall_id")
+	if _, ok := c.firewallVPCs[firewallID]; !ok {
+		return nil, notFound("firewall", firewallID)
+	}
+	return c.startJob(req, n.ID, func() { n.FirewallID = firewallID }), nil
+}
+
+func (c *Cloud) networkDelete(req *request) (interface{}, error) {
+	n, err := c.network(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.startJob(req, n.ID, func() { delete(c.networks, n.ID) }), nil
+}
+
+func (c *Cloud) floatingIP(req *request) (*gocmcapi.FloatingIP, error) {
+	f, ok := c.floatingIPs[req.str("id")]
+	if !ok {
+		return nil, notFound("floating ip", req.str("id"))
+	}
+	return f, nil
+}
+
+func (c *Cloud) floatingIPInfo(req *request) (interface{}, error) {
+	return c.floatingIP(req)
+}
+
+func (c *Cloud) floatingIPCreate(req *request) (interface{}, error) {
+	v, err := c.vpc(req, "vpc_id")
+	if err != nil {
+		return nil, err
+	}
+	f := &gocmcapi.FloatingIP{
+		ID:         c.newID(),
+		RegionName: v.RegionName,
+		RegionID:   v.RegionID,
+		State:      "Allocated",
+		VPCID:      v.ID,
+	}
+	f.IPAddress = fmt.Sprintf("203.0.113.%d", c.seq%254+1)
+	return c.startOrder(req, f.ID, func() { c.floatingIPs[f.ID] = f }), nil
+}
+
+func (c *Cloud) floatingIPDelete(req *request) (interface{}, error) {
+	f, err := c.floatingIP(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.startJob(req, f.ID, func() { delete(c.floatingIPs, f.ID) }), nil
+}
+
+func (c *Cloud) firewallVPC(req *request) (*gocmcapi.FirewallVPC, error) {
+	f, ok := c.firewallVPCs[req.str("id")]
+	if !ok {
+		return nil, notFound("firewall", req.str("id"))
+	}
+	return f, nil
+}
+
+func (c *Cloud) firewallVPCInfo(req *request) (interface{}, error) {
+	return c.firewallVPC(req)
+}
+
+func (c *Cloud) firewallVPCCreate(req *request) (interface{}, error) {
+	v, err := c.vpc(req, "vpc_id")
+	if err != nil {
+		return nil, err
+	}
+	f := &gocmcapi.FirewallVPC{
+		ID:            c.newID(),
+		Name:          req.str("name"),
+		Description:   req.str("description"),
+		VPCID:         v.ID,
+		InboundRules:  []gocmcapi.FirewallVPCRule{},
+		OutboundRules: []gocmcapi.FirewallVPCRule{},
+	}
+	return c.startJob(req, f.ID, func() { c.firewallVPCs[f.ID] = f }), nil
+}
+
+func (c *Cloud) firewallVPCUpdate(req *request) (interface{}, error) {
+	f, err := c.firewallVPC(req)
+	if err != nil {
+		return nil, err
+	}
+	name, description := req.str("name"), req.str("description")
+	return c.startJob(req, f.ID, func() {
+		f.Name = name
+		f.Description = description
+	}), nil
+}
+
+func (c *Cloud) firewallVPCDelete(req *request) (interface{}, error) {
+	f, err := c.firewallVPC(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.startJob(req, f.ID, func() { delete(c.firewallVPCs, f.ID) }), nil
+}
+
+// ruleFromRequest builds a rule from the params of create_rule and update_rule
+func ruleFromRequest(req *request, id string) gocmcapi.FirewallVPCRule {
+	var cidrs []string
+	for _, cidr := range strings.Split(req.str("cidrs"), ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return gocmcapi.FirewallVPCRule{
+		ID:        id,
+		Protocol:  req.str("protocol"),
+		Action:    req.str("action"),
+		Cidrs:     cidrs,
+		PortRange: req.str("port_range"),
+	}
+}
+
+func (c *Cloud) firewallVPCCreateRule(req *request) (interface{}, error) {
+	f, err := c.firewallVPC(req)
+	if err != nil {
+		return nil, err
+	}
+	rule := ruleFromRequest(req, c.newID())
+	outbound := req.str("type") == "outbound"
+	return c.startJob(req, rule.ID, func() {
+		if outbound {
+			f.OutboundRules = append(f.OutboundRules, rule)
+		} else {
+			f.InboundRules = append(f.InboundRules, rule)
+		}
+	}), nil
+}
+
+// findRule returns the firewall rule with the given id
+func (c *Cloud) findRule(id string) *gocmcapi.FirewallVPCRule {
+	for _, f := range c.firewallVPCs {
+		for _, rules := range [][]gocmcapi.FirewallVPCRule{f.InboundRules, f.OutboundRules} {
+			for i := range rules {
+				if rules[i].ID == id {
+					return &rules[i]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Cloud) firewallVPCUpdateRule(req *request) (interface{}, error) {
+	id := req.str("rule_id")
+	if id == "" {
+		id = req.str("id")
+	}
+	rule := c.findRule(id)
+	if rule == nil {
+		return nil, notFound("firewall rule", id)
+	}
+	updated := ruleFromRequest(req, id)
+	return c.startJob(req, id, func() { *rule = updated }), nil
+}
+
+func (c *Cloud) firewallVPCDeleteRule(req *request) (interface{}, error) {
+	id := req.str("id")
+	if c.findRule(id) == nil {
+		return nil, notFound("firewall rule", id)
+	}
+	return c.startJob(req, id, func() {
+		for _, f := range c.firewallVPCs {
+			f.InboundRules = withoutRule(f.InboundRules, id)
+			f.OutboundRules = withoutRule(f.OutboundRules, id)
+		}
+	}), nil
+}
+
+func withoutRule(rules []gocmcapi.FirewallVPCRule, id string) []gocmcapi.FirewallVPCRule {
+	kept := rules[:0]
+	for _, rule := range rules {
+		if rule.ID != id {
+			kept = append(kept, rule)
+		}
+	}
+	return kept
+}
+
+func (c *Cloud) firewallVPCGetRules(req *request) (interface{}, error) {
+	f, err := c.firewallVPC(req)
+	if err != nil {
+		return nil, err
+	}
+	rules := append([]gocmcapi.FirewallVPCRule{}, f.InboundRules...)
+	return append(rules, f.OutboundRules...), nil
+}
+
+// parseVPCRules parses the json rules sent to save_rules and validate_rules
+func parseVPCRules(kind, rules string) ([]gocmcapi.FirewallVPCRule, error) {
+	parsed := []gocmcapi.FirewallVPCRule{}
+	if strings.TrimSpace(rules) == "" {
+		return parsed, nil
+	}
+	if err := json.Unmarshal([]byte(rules), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid %s rules: %s", kind, err)
+	}
+	return parsed, nil
+}
+
+func (c *Cloud) firewallVPCSaveRules(req *request) (interface{}, error) {
+	f, err := c.firewallVPC(req)
+	if err != nil {
+		return nil, err
+	}
+	inbound, err := parseVPCRules("inbound", req.str("inbound_rules"))
+	if err != nil {
+		return nil, badRequest("%s", err)
+	}
+	outbound, err := parseVPCRules("outbound", req.str("outbound_rules"))
+	if err != nil {
+		return nil, badRequest("%s", err)
+	}
+	for _, rules := range [][]gocmcapi.FirewallVPCRule{inbound, outbound} {
+		for i := range rules {
+			if rules[i].ID == "" {
+				rules[i].ID = c.newID()
+			}
+		}
+	}
+	return c.startJob(req, f.ID, func() {
+		f.InboundRules = inbound
+		f.OutboundRules = outbound
+	}), nil
+}
+
+func (c *Cloud) firewallVPCValidateRules(req *request) (interface{}, error) {
+	errors := []string{}
+	if _, err := parseVPCRules("inbound", req.str("inbound_rules")); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if _, err := parseVPCRules("outbound", req.str("outbound_rules")); err != nil {
+		errors = append(errors, err.Error())
+	}
+	return errors, nil
+}
+
+// firewallDirect returns the firewall of a server ip, it is created empty on first use
+func (c *Cloud) firewallDirect(serverID, ipAddress string) (*gocmcapi.FirewallDirect, error) {
+	if _, ok := c.servers[serverID]; !ok {
+		return nil, notFound("server", serverID)
+	}
+	key := serverID + "/" + ipAddress
+	f, ok := c.firewallDirects[key]
+	if !ok {
+		f = &gocmcapi.FirewallDirect{
+			ServerID:      serverID,
+			IPAddress:     ipAddress,
+			InboundRules:  []gocmcapi.FirewallDirectRule{},
+			OutboundRules: []gocmcapi.FirewallDirectRule{},
+		}
+		c.firewallDirects[key] = f
+	}
+	return f, nil
+}
+
+func (c *Cloud) firewallDirectInfo(req *request) (interface{}, error) {
+	return c.firewallDirect(req.str("server_id"), req.str("ip_address"))
+}
+
+func (c *Cloud) firewallDirectSaveRules(req *request) (interface{}, error) {
+	f, err := c.firewallDirect(req.str("server_id"), req.str("ip_address"))
+	if err != nil {
+		return nil, err
+	}
+	inbound := []gocmcapi.FirewallDirectRule{}
+	outbound := []gocmcapi.FirewallDirectRule{}
+	for _, rules := range []struct {
+		kind   string
+		value  string
+		parsed *[]gocmcapi.FirewallDirectRule
+	}{{"inbound", req.str("inbound_rules"), &inbound}, {"outbound", req.str("outbound_rules"), &outbound}} {
+		if strings.TrimSpace(rules.value) == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(rules.value), rules.parsed); err != nil {
+			return nil, badRequest("invalid %s rules: %s", rules.kind, err)
+		}
+	}
+	return c.startJob(req, f.ServerID, func() {
+		f.InboundRules = inbound
+		f.OutboundRules = outbound
+	}), nil
+}
+
+func (c *Cloud) firewallDirectDelete(req *request) (interface{}, error) {
+	f, err := c.firewallDirect(req.str("id"), req.str("ip_address"))
+	if err != nil {
+		return nil, err
+	}
+	return c.startJob(req, f.ServerID, func() {
+		delete(c.firewallDirects, f.ServerID+"/"+f.IPAddress)
+	}), nil
+}
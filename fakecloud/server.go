@@ -0,0 +1,273 @@
+package fakecloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+)
+
+func (c *Cloud) serverRoutes() {
+	c.routes["server/info"] = c.serverInfo
+	c.routes["server/create"] = c.serverCreate
+	c.routes["server_action/delete"] = c.serverDelete
+	c.routes["server_action/rename"] = c.serverRename
+	c.routes["server_action/update_schedule_time"] = c.serverUpdateScheduleTime
+	c.routes["server_action/add_secondary_ip"] = c.serverAddSecondaryIP
+	c.routes["server_action/remove_secondary_ip"] = c.serverRemoveSecondaryIP
+	c.routes["server_action/add_nic"] = c.serverAddNic
+	c.routes["server_action/remove_nic"] = c.serverRemoveNic
+	c.routes["server_action/enable_backup"] = c.serverEnableBackup
+	c.routes["server_action/disable_backup"] = c.serverDisableBackup
+	c.routes["server_action/enable_private_network"] = c.serverTask(nil)
+	c.routes["server_action/disable_private_network"] = c.serverTask(nil)
+	c.routes["server_action/reset_pass"] = c.serverTask(nil)
+	c.routes["server_action/restart"] = c.serverTask(func(s *gocmcapi.Server) { s.State = "running" })
+	c.routes["server_action/start"] = c.serverTask(func(s *gocmcapi.Server) { s.State = "running" })
+	c.routes["server_action/stop"] = c.serverTask(func(s *gocmcapi.Server) { s.State = "stopped" })
+	c.routes["server_action/restore_snapshot"] = c.serverRestoreSnapshot
+	c.routes["server_action/take_snapshot"] = c.serverTakeSnapshot
+	c.routes["server_action/resize"] = c.serverResize
+	c.routes["server_action/console"] = c.serverConsole
+}
+
+// AddServer adds a server to the model, an empty ID is generated
+func (c *Cloud) AddServer(s gocmcapi.Server) gocmcapi.Server {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s.ID == "" {
+		s.ID = c.newID()
+	}
+	c.servers[s.ID] = &s
+	return s
+}
+
+// Server returns a server of the model
+func (c *Cloud) Server(id string) (gocmcapi.Server, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.servers[id]
+	if !ok {
+		return gocmcapi.Server{}, false
+	}
+	return *s, true
+}
+
+// ServerByName returns a server of the model by name, e.g. to reconcile a
+// server/create whose answer was dropped
+func (c *Cloud) ServerByName(name string) (gocmcapi.Server, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.servers {
+		if s.Name == name {
+			return *s, true
+		}
+	}
+	return gocmcapi.Server{}, false
+}
+
+func (c *Cloud) server(req *request) (*gocmcapi.Server, error) {
+	s, ok := c.servers[req.str("id")]
+	if !ok {
+		return nil, notFound("server", req.str("id"))
+	}
+	return s, nil
+}
+
+func (c *Cloud) serverInfo(req *request) (interface{}, error) {
+	return c.server(req)
+}
+
+func (c *Cloud) serverCreate(req *request) (interface{}, error) {
+	if req.str("name") == "" {
+		return nil, badRequest("name is required")
+	}
+	s := &gocmcapi.Server{
+		ID:            c.newID(),
+		Name:          req.str("name"),
+		DisplayName:   req.str("name"),
+		Created:       time.Now().UTC().Format(time.RFC3339),
+		Bits:          64,
+		State:         "running",
+		MainIPAddress: fmt.Sprintf("10.0.%d.%d", c.seq/250, c.seq%250+1),
+		ImageID:       req.str("image_id"),
+		CPU:           req.int("cpu"),
+		RAM:           req.int("ram"),
+		Root:          req.int("root"),
+		GPU:           req.int("gpu"),
+	}
+	s.RegionID, s.RegionName = c.region(req.str("region"))
+	s.Nics = []gocmcapi.Nic{{ID: c.newID(), IP4Address: s.MainIPAddress, DefaultNic: true, IPType: "public"}}
+	return c.startOrder(req, s.ID, func() { c.servers[s.ID] = s }), nil
+}
+
+func (c *Cloud) serverDelete(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.startJob(req, s.ID, func() { delete(c.servers, s.ID) }), nil
+}
+
+func (c *Cloud) serverRename(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	s.Name = req.str("name")
+	s.DisplayName = req.str("name")
+	return map[string]interface{}{"success": true}, nil
+}
+
+func (c *Cloud) serverUpdateScheduleTime(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	s.BackupSchedule = req.str("interval_type") + " " + req.str("schedule_time")
+	return map[string]interface{}{"success": true}, nil
+}
+
+func (c *Cloud) serverAddSecondaryIP(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	nic := gocmcapi.Nic{ID: c.newID(), IP4Address: fmt.Sprintf("10.1.%d.%d", c.seq/250, c.seq%250+1), IPType: "secondary"}
+	return c.startOrder(req, nic.ID, func() { s.Nics = append(s.Nics, nic) }), nil
+}
+
+func (c *Cloud) serverRemoveSecondaryIP(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	ip := req.str("ip4_address")
+	return c.startJob(req, s.ID, func() {
+		removeNics(s, func(n gocmcapi.Nic) bool { return n.IP4Address == ip })
+	}), nil
+}
+
+func (c *Cloud) serverAddNic(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	network, ok := c.networks[req.str("network_id")]
+	if !ok {
+		return nil, notFound("network", req.str("network_id"))
+	}
+	nic := gocmcapi.Nic{ID: c.newID(), NetworkID: network.ID, Gateway: network.Gateway, Netmask: network.Netmask, IsVPC: network.VPCID != "", IPType: "private"}
+	return c.startJob(req, nic.ID, func() {
+		s.Nics = append(s.Nics, nic)
+		network.ServerIDs = append(network.ServerIDs, s.ID)
+	}), nil
+}
+
+func (c *Cloud) serverRemoveNic(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	nicID := req.str("nic_id")
+	return c.startJob(req, s.ID, func() {
+		removeNics(s, func(n gocmcapi.Nic) bool { return n.ID == nicID })
+	}), nil
+}
+
+func removeNics(s *gocmcapi.Server, remove func(gocmcapi.Nic) bool) {
+	nics := s.Nics[:0]
+	for _, n := range s.Nics {
+		if !remove(n) {
+			nics = append(nics, n)
+		}
+	}
+	s.Nics = nics
+}
+
+func (c *Cloud) serverEnableBackup(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	schedule := req.str("interval_type") + " " + req.str("schedule_time")
+	return c.startOrder(req, s.ID, func() {
+		s.AutoBackup = true
+		s.BackupSchedule = schedule
+	}), nil
+}
+
+func (c *Cloud) serverDisableBackup(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.startJob(req, s.ID, func() { s.AutoBackup = false }), nil
+}
+
+// serverTask handles a server action that only starts a job, apply may be nil
+func (c *Cloud) serverTask(apply func(s *gocmcapi.Server)) handlerFunc {
+	return func(req *request) (interface{}, error) {
+		s, err := c.server(req)
+		if err != nil {
+			return nil, err
+		}
+		return c.startJob(req, s.ID, func() {
+			if apply != nil {
+				apply(s)
+			}
+		}), nil
+	}
+}
+
+func (c *Cloud) serverRestoreSnapshot(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.snapshots[req.str("snapshot_id")]; !ok {
+		return nil, notFound("snapshot", req.str("snapshot_id"))
+	}
+	return c.startJob(req, s.ID, func() { s.State = "running" }), nil
+}
+
+func (c *Cloud) serverTakeSnapshot(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &gocmcapi.Snapshot{
+		ID:       c.newID(),
+		Name:     req.str("name"),
+		Size:     s.Root,
+		State:    "BackedUp",
+		Created:  time.Now().UTC().Format(time.RFC3339),
+		ServerID: s.ID,
+	}
+	return c.startOrder(req, snapshot.ID, func() { c.snapshots[snapshot.ID] = snapshot }), nil
+}
+
+func (c *Cloud) serverResize(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	cpu, ram, root, gpu := req.int("cpu"), req.int("ram"), req.int("disk"), req.int("gpu")
+	if root > 0 && root < s.Root {
+		return nil, badRequest("root disk can not be shrunk from %d to %d GB", s.Root, root)
+	}
+	return c.startOrder(req, s.ID, func() {
+		s.CPU, s.RAM, s.GPU = cpu, ram, gpu
+		if root > 0 {
+			s.Root = root
+		}
+	}), nil
+}
+
+func (c *Cloud) serverConsole(req *request) (interface{}, error) {
+	s, err := c.server(req)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"url": "https://console.fakecloud.local/" + s.ID}, nil
+}
@@ -0,0 +1,170 @@
+package fakecloud
+
+import (
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+)
+
+func (c *Cloud) storageRoutes() {
+	c.routes["volume/info"] = c.volumeInfo
+	c.routes["volume/create"] = c.volumeCreate
+	c.routes["volume/delete"] = c.volumeDelete
+	c.routes["volume/rename"] = c.volumeRename
+	c.routes["volume/resize"] = c.volumeResize
+	c.routes["volume/attach"] = c.volumeAttach
+	c.routes["volume/detach"] = c.volumeDetach
+	c.routes["snapshot/info"] = c.snapshotInfo
+	c.routes["snapshot/create"] = c.snapshotCreate
+	c.routes["snapshot/delete"] = c.snapshotDelete
+	c.routes["snapshot/rename"] = c.snapshotRename
+}
+
+// Volume returns a volume of the model
+func (c *Cloud) Volume(id string) (gocmcapi.Volume, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.volumes[id]
+	if !ok {
+		return gocmcapi.Volume{}, false
+	}
+	return *v, true
+}
+
+// Snapshot returns a snapshot of the model
+func (c *Cloud) Snapshot(id string) (gocmcapi.Snapshot, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.snapshots[id]
+	if !ok {
+		return gocmcapi.Snapshot{}, false
+	}
+	return *s, true
+}
+
+func (c *Cloud) volume(req *request) (*gocmcapi.Volume, error) {
+	v, ok := c.volumes[req.str("id")]
+	if !ok {
+		return nil, notFound("volume", req.str("id"))
+	}
+	return v, nil
+}
+
+func (c *Cloud) volumeInfo(req *request) (interface{}, error) {
+	return c.volume(req)
+}
+
+func (c *Cloud) volumeCreate(req *request) (interface{}, error) {
+	if req.int("size") <= 0 {
+		return nil, badRequest("size must be positive")
+	}
+	v := &gocmcapi.Volume{
+		ID:      c.newID(),
+		Name:    req.str("name"),
+		Size:    req.int("size"),
+		Type:    req.str("type"),
+		State:   "Ready",
+		Created: time.Now().UTC().Format(time.RFC3339),
+	}
+	v.RegionID, v.Region = c.region(req.str("region"))
+	return c.startOrder(req, v.ID, func() { c.volumes[v.ID] = v }), nil
+}
+
+func (c *Cloud) volumeDelete(req *request) (interface{}, error) {
+	v, err := c.volume(req)
+	if err != nil {
+		return nil, err
+	}
+	if v.ServerID != "" {
+		return nil, badRequest("volume %s is attached to server %s", v.ID, v.ServerID)
+	}
+	return c.startJob(req, v.ID, func() { delete(c.volumes, v.ID) }), nil
+}
+
+func (c *Cloud) volumeRename(req *request) (interface{}, error) {
+	v, err := c.volume(req)
+	if err != nil {
+		return nil, err
+	}
+	v.Name = req.str("name")
+	return map[string]interface{}{"success": true}, nil
+}
+
+func (c *Cloud) volumeResize(req *request) (interface{}, error) {
+	v, err := c.volume(req)
+	if err != nil {
+		return nil, err
+	}
+	size := req.int("size")
+	if size < v.Size {
+		return nil, badRequest("volume can not be shrunk from %d to %d GB", v.Size, size)
+	}
+	return c.startOrder(req, v.ID, func() { v.Size = size }), nil
+}
+
+func (c *Cloud) volumeAttach(req *request) (interface{}, error) {
+	v, err := c.volume(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.servers[req.str("server_id")]; !ok {
+		return nil, notFound("server", req.str("server_id"))
+	}
+	v.ServerID = req.str("server_id")
+	return map[string]interface{}{"success": true}, nil
+}
+
+func (c *Cloud) volumeDetach(req *request) (interface{}, error) {
+	v, err := c.volume(req)
+	if err != nil {
+		return nil, err
+	}
+	v.ServerID = ""
+	return map[string]interface{}{"success": true}, nil
+}
+
+func (c *Cloud) snapshot(req *request) (*gocmcapi.Snapshot, error) {
+	s, ok := c.snapshots[req.str("id")]
+	if !ok {
+		return nil, notFound("snapshot", req.str("id"))
+	}
+	return s, nil
+}
+
+func (c *Cloud) snapshotInfo(req *request) (interface{}, error) {
+	return c.snapshot(req)
+}
+
+func (c *Cloud) snapshotCreate(req *request) (interface{}, error) {
+	v, ok := c.volumes[req.str("volume_id")]
+	if !ok {
+		return nil, notFound("volume", req.str("volume_id"))
+	}
+	s := &gocmcapi.Snapshot{
+		ID:       c.newID(),
+		Name:     req.str("name"),
+		Size:     v.Size,
+		State:    "BackedUp",
+		Created:  time.Now().UTC().Format(time.RFC3339),
+		VolumeID: v.ID,
+		ServerID: v.ServerID,
+	}
+	return c.startOrder(req, s.ID, func() { c.snapshots[s.ID] = s }), nil
+}
+
+func (c *Cloud) snapshotDelete(req *request) (interface{}, error) {
+	s, err := c.snapshot(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.startJob(req, s.ID, func() { delete(c.snapshots, s.ID) }), nil
+}
+
+func (c *Cloud) snapshotRename(req *request) (interface{}, error) {
+	s, err := c.snapshot(req)
+	if err != nil {
+		return nil, err
+	}
+	s.Name = req.str("name")
+	return map[string]interface{}{"success": true}, nil
+}
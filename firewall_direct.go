@@ -1,14 +1,20 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
 )
 
 // FirewallDirectService interface
 type FirewallDirectService interface {
 	Get(serverID string, ipAddress string) (FirewallDirect, error)
-	Delete(serverID string, ipAddress string) (TaskStatus, error)
-	SaveRules(erverID string, ipAddress string, inboundRules string, outboundRules string) (TaskStatus, error)
+	GetWithContext(ctx context.Context, serverID string, ipAddress string) (FirewallDirect, error)
+	Delete(serverID string, ipAddress string, opts ...CallOption) (TaskStatus, error)
+	DeleteWithContext(ctx context.Context, serverID string, ipAddress string, opts ...CallOption) (TaskStatus, error)
+	DeleteAsync(ctx context.Context, serverID string, ipAddress string, opts ...CallOption) (*TaskHandle, error)
+	SaveRules(erverID string, ipAddress string, inboundRules string, outboundRules string, opts ...CallOption) (TaskStatus, error)
+	SaveRulesWithContext(ctx context.Context, erverID string, ipAddress string, inboundRules string, outboundRules string, opts ...CallOption) (TaskStatus, error)
+	SaveRulesAsync(ctx context.Context, serverID string, ipAddress string, inboundRules string, outboundRules string, opts ...CallOption) (*TaskHandle, error)
 }
 
 // FirewallDirectRule object
@@ -37,7 +43,12 @@ type firewalldirect struct {
 
 // Get FirewallDirect detail
 func (v *firewalldirect) Get(serverID string, ipAddress string) (FirewallDirect, error) {
-	jsonStr, err := v.client.Get("firewall_direct/info", map[string]string{"server_id": serverID, "ip_address": ipAddress})
+	return v.GetWithContext(context.Background(), serverID, ipAddress)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (v *firewalldirect) GetWithContext(ctx context.Context, serverID string, ipAddress string) (FirewallDirect, error) {
+	jsonStr, err := v.client.GetWithContext(ctx, "firewall_direct/info", map[string]string{"server_id": serverID, "ip_address": ipAddress})
 	var firewall FirewallDirect
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &firewall)
@@ -46,10 +57,31 @@ func (v *firewalldirect) Get(serverID string, ipAddress string) (FirewallDirect,
 }
 
 // Delete a FirewallDirect
-func (v *firewalldirect) Delete(serverID string, ipAddress string) (TaskStatus, error) {
-	return v.client.LongDeleteTask("firewall_direct/delete", serverID, map[string]string{"ip_address": ipAddress}, MediumTimeSettings)
+func (v *firewalldirect) Delete(serverID string, ipAddress string, opts ...CallOption) (TaskStatus, error) {
+	return v.DeleteWithContext(context.Background(), serverID, ipAddress, opts...)
+}
+
+// DeleteWithContext same as Delete, cancellable through ctx
+func (v *firewalldirect) DeleteWithContext(ctx context.Context, serverID string, ipAddress string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.DeleteAsync(ctx, serverID, ipAddress, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DeleteAsync same as Delete, returns once the task is submitted
+func (v *firewalldirect) DeleteAsync(ctx context.Context, serverID string, ipAddress string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongDeleteTaskAsync(ctx, "firewall_direct/delete", serverID, map[string]string{"ip_address": ipAddress}, MediumTimeSettings, opts...)
+}
+
+func (v *firewalldirect) SaveRules(serverID string, ipAddress string, inboundRules string, outboundRules string, opts ...CallOption) (TaskStatus, error) {
+	return v.SaveRulesWithContext(context.Background(), serverID, ipAddress, inboundRules, outboundRules, opts...)
+}
+
+func (v *firewalldirect) SaveRulesWithContext(ctx context.Context, serverID string, ipAddress string, inboundRules string, outboundRules string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.SaveRulesAsync(ctx, serverID, ipAddress, inboundRules, outboundRules, opts...)
+	return waitTask(ctx, handle, err)
 }
 
-func (v *firewalldirect) SaveRules(serverID string, ipAddress string, inboundRules string, outboundRules string) (TaskStatus, error) {
-	return v.client.LongTask("firewall_direct/save_rules", "", map[string]interface{}{"server_id": serverID, "ip_address": ipAddress, "inbound_rules": inboundRules, "outbound_rules": outboundRules}, MediumTimeSettings)
+// SaveRulesAsync same as SaveRules, returns once the task is submitted
+func (v *firewalldirect) SaveRulesAsync(ctx context.Context, serverID string, ipAddress string, inboundRules string, outboundRules string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "firewall_direct/save_rules", "", map[string]interface{}{"server_id": serverID, "ip_address": ipAddress, "inbound_rules": inboundRules, "outbound_rules": outboundRules}, MediumTimeSettings, opts...)
 }
@@ -1,22 +1,41 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // FirewallVPCService interface
 type FirewallVPCService interface {
 	Get(id string) (FirewallVPC, error)
-	Create(vpcID, name, description string) (TaskStatus, error)
-	Delete(id string) (TaskStatus, error)
-	Update(id string, name string, description string) error
-	DeleteAllRules(id string) error
-	CreateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string) (TaskStatus, error)
-	UpdateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string) (TaskStatus, error)
+	GetWithContext(ctx context.Context, id string) (FirewallVPC, error)
+	Create(vpcID, name, description string, opts ...CallOption) (TaskStatus, error)
+	CreateWithContext(ctx context.Context, vpcID, name, description string, opts ...CallOption) (TaskStatus, error)
+	CreateAsync(ctx context.Context, vpcID, name, description string, opts ...CallOption) (*TaskHandle, error)
+	Delete(id string, opts ...CallOption) (TaskStatus, error)
+	DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	Update(id string, name string, description string, opts ...CallOption) error
+	UpdateWithContext(ctx context.Context, id string, name string, description string, opts ...CallOption) error
+	UpdateAsync(ctx context.Context, id string, name string, description string, opts ...CallOption) (*TaskHandle, error)
+	DeleteAllRules(id string, opts ...CallOption) error
+	DeleteAllRulesWithContext(ctx context.Context, id string, opts ...CallOption) error
+	DeleteAllRulesAsync(ctx context.Context, id string, opts ...CallOption) ([]*TaskHandle, error)
+	CreateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (TaskStatus, error)
+	CreateRuleWithContext(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (TaskStatus, error)
+	CreateRuleAsync(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (*TaskHandle, error)
+	UpdateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (TaskStatus, error)
+	UpdateRuleWithContext(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (TaskStatus, error)
+	UpdateRuleAsync(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (*TaskHandle, error)
 	GetRules(id string) ([]interface{}, error)
+	GetRulesWithContext(ctx context.Context, id string) ([]interface{}, error)
 	ValidateRules(inboundRules string, outboundRules string) ([]string, error)
-	SaveRules(id string, inboundRules string, outboundRules string) (TaskStatus, error)
+	ValidateRulesWithContext(ctx context.Context, inboundRules string, outboundRules string) ([]string, error)
+	SaveRules(id string, inboundRules string, outboundRules string, opts ...CallOption) (TaskStatus, error)
+	SaveRulesWithContext(ctx context.Context, id string, inboundRules string, outboundRules string, opts ...CallOption) (TaskStatus, error)
+	SaveRulesAsync(ctx context.Context, id string, inboundRules string, outboundRules string, opts ...CallOption) (*TaskHandle, error)
 }
 
 // FirewallVPCRule object
@@ -43,7 +62,12 @@ type firewallvpc struct {
 
 // Get FirewallVPC detail
 func (v *firewallvpc) Get(id string) (FirewallVPC, error) {
-	jsonStr, err := v.client.Get("firewall_vpc/info", map[string]string{"id": id})
+	return v.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (v *firewallvpc) GetWithContext(ctx context.Context, id string) (FirewallVPC, error) {
+	jsonStr, err := v.client.GetWithContext(ctx, "firewall_vpc/info", map[string]string{"id": id})
 	var firewall FirewallVPC
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &firewall)
@@ -52,36 +76,87 @@ func (v *firewallvpc) Get(id string) (FirewallVPC, error) {
 }
 
 // Delete a FirewallVPC
-func (v *firewallvpc) Delete(id string) (TaskStatus, error) {
-	return v.client.LongDeleteTask("firewall_vpc/delete", id, nil, MediumTimeSettings)
+func (v *firewallvpc) Delete(id string, opts ...CallOption) (TaskStatus, error) {
+	return v.DeleteWithContext(context.Background(), id, opts...)
+}
+
+// DeleteWithContext same as Delete, cancellable through ctx
+func (v *firewallvpc) DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.DeleteAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DeleteAsync same as Delete, returns once the task is submitted
+func (v *firewallvpc) DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongDeleteTaskAsync(ctx, "firewall_vpc/delete", id, nil, MediumTimeSettings, opts...)
 }
-func (v *firewallvpc) Update(id string, name string, description string) error {
-	_, err := v.client.LongTask("firewall_vpc/update", id, map[string]interface{}{"name": name, "description": description}, ShortTimeSettings)
+func (v *firewallvpc) Update(id string, name string, description string, opts ...CallOption) error {
+	return v.UpdateWithContext(context.Background(), id, name, description, opts...)
+}
+
+func (v *firewallvpc) UpdateWithContext(ctx context.Context, id string, name string, description string, opts ...CallOption) error {
+	handle, err := v.UpdateAsync(ctx, id, name, description, opts...)
+	_, err = waitTask(ctx, handle, err)
 	return err
 }
 
+// UpdateAsync same as Update, returns once the task is submitted
+func (v *firewallvpc) UpdateAsync(ctx context.Context, id string, name string, description string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "firewall_vpc/update", id, map[string]interface{}{"name": name, "description": description}, ShortTimeSettings, opts...)
+}
+
 /*
 func (v *firewallvpc) DeleteAllRules(id string) error {
 	_, err := v.client.LongTask("firewall_vpc/delete_all_rules", id, nil, MediumTimeSettings)
 	return err
 }
 */
-func (v *firewallvpc) Create(vpcID, name, description string) (TaskStatus, error) {
-	return v.client.LongTask("firewall_vpc/create", "", map[string]interface{}{"name": name, "description": description, "vpc_id": vpcID}, ShortTimeSettings)
+func (v *firewallvpc) Create(vpcID, name, description string, opts ...CallOption) (TaskStatus, error) {
+	return v.CreateWithContext(context.Background(), vpcID, name, description, opts...)
+}
+
+func (v *firewallvpc) CreateWithContext(ctx context.Context, vpcID, name, description string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.CreateAsync(ctx, vpcID, name, description, opts...)
+	return waitTask(ctx, handle, err)
 }
-func (v *firewallvpc) CreateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string) (TaskStatus, error) {
-	return v.client.LongTask("firewall_vpc/create_rule", id, map[string]interface{}{
+
+// CreateAsync same as Create, returns once the task is submitted
+func (v *firewallvpc) CreateAsync(ctx context.Context, vpcID, name, description string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "firewall_vpc/create", "", map[string]interface{}{"name": name, "description": description, "vpc_id": vpcID}, ShortTimeSettings, opts...)
+}
+func (v *firewallvpc) CreateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (TaskStatus, error) {
+	return v.CreateRuleWithContext(context.Background(), id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+}
+
+func (v *firewallvpc) CreateRuleWithContext(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.CreateRuleAsync(ctx, id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// CreateRuleAsync same as CreateRule, returns once the task is submitted
+func (v *firewallvpc) CreateRuleAsync(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "firewall_vpc/create_rule", id, map[string]interface{}{
 		"number":     number,
 		"cidrs":      cidrs,
 		"action":     action,
 		"protocol":   protocol,
 		"type":       ruleType,
 		"port_range": portRange,
-	}, ShortTimeSettings)
+	}, ShortTimeSettings, opts...)
 }
 
-func (v *firewallvpc) UpdateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string) (TaskStatus, error) {
-	return v.client.LongTask("firewall_vpc/update_rule", id, map[string]interface{}{
+func (v *firewallvpc) UpdateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (TaskStatus, error) {
+	return v.UpdateRuleWithContext(context.Background(), id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+}
+
+func (v *firewallvpc) UpdateRuleWithContext(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.UpdateRuleAsync(ctx, id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// UpdateRuleAsync same as UpdateRule, returns once the task is submitted
+func (v *firewallvpc) UpdateRuleAsync(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "firewall_vpc/update_rule", id, map[string]interface{}{
 		"rule_id":    id,
 		"number":     number,
 		"cidrs":      cidrs,
@@ -89,11 +164,15 @@ func (v *firewallvpc) UpdateRule(id string, number int, cidrs string, action str
 		"protocol":   protocol,
 		"type":       ruleType,
 		"port_range": portRange,
-	}, ShortTimeSettings)
+	}, ShortTimeSettings, opts...)
 }
 
 func (v *firewallvpc) GetRules(id string) ([]interface{}, error) {
-	jsonStr, err := v.client.Get("firewall_vpc/get_rules", map[string]string{"id": id})
+	return v.GetRulesWithContext(context.Background(), id)
+}
+
+func (v *firewallvpc) GetRulesWithContext(ctx context.Context, id string) ([]interface{}, error) {
+	jsonStr, err := v.client.GetWithContext(ctx, "firewall_vpc/get_rules", map[string]string{"id": id})
 	var firewalls []interface{}
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &firewalls)
@@ -101,40 +180,87 @@ func (v *firewallvpc) GetRules(id string) ([]interface{}, error) {
 	return firewalls, err
 }
 
-func (v *firewallvpc) SaveRules(id string, inboundRules string, outboundRules string) (TaskStatus, error) {
-	return v.client.LongTask("firewall_vpc/save_rules", id, map[string]interface{}{"inbound_rules": inboundRules, "outbound_rules": outboundRules}, MediumTimeSettings)
+func (v *firewallvpc) SaveRules(id string, inboundRules string, outboundRules string, opts ...CallOption) (TaskStatus, error) {
+	return v.SaveRulesWithContext(context.Background(), id, inboundRules, outboundRules, opts...)
 }
 
-func (v *firewallvpc) DeleteAllRules(id string) error {
-	rules, err := v.GetRules(id)
+func (v *firewallvpc) SaveRulesWithContext(ctx context.Context, id string, inboundRules string, outboundRules string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.SaveRulesAsync(ctx, id, inboundRules, outboundRules, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// SaveRulesAsync same as SaveRules, returns once the task is submitted
+func (v *firewallvpc) SaveRulesAsync(ctx context.Context, id string, inboundRules string, outboundRules string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "firewall_vpc/save_rules", id, map[string]interface{}{"inbound_rules": inboundRules, "outbound_rules": outboundRules}, MediumTimeSettings, opts...)
+}
+
+func (v *firewallvpc) DeleteAllRules(id string, opts ...CallOption) error {
+	return v.DeleteAllRulesWithContext(context.Background(), id, opts...)
+}
+
+func (v *firewallvpc) DeleteAllRulesWithContext(ctx context.Context, id string, opts ...CallOption) error {
+	handles, err := v.DeleteAllRulesAsync(ctx, id, opts...)
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	// the deletions submitted before a failure run anyway, wait for all of
+	// them so their spans and journal entries are finished
+	for _, handle := range handles {
+		if _, err := handle.Wait(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("Error when delete rule, task id %s, %+v", handle.TaskID, err))
+		}
+		// ends the span of a handle whose wait was cancelled with ctx
+		handle.Stop()
+	}
+	return joinErrors(errs)
+}
+
+// joinErrors returns nil without errs, else the first one followed by the
+// messages of the others
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	others := make([]string, 0, len(errs)-1)
+	for _, err := range errs[1:] {
+		others = append(others, err.Error())
+	}
+	return fmt.Errorf("%w; %s", errs[0], strings.Join(others, "; "))
+}
+
+// DeleteAllRulesAsync same as DeleteAllRules, returns once the deletion of
+// every rule is submitted. When a deletion fails, the handles of the
+// deletions submitted before are returned with the error. opts apply to every
+// firewall_vpc/delete_rule task
+func (v *firewallvpc) DeleteAllRulesAsync(ctx context.Context, id string, opts ...CallOption) ([]*TaskHandle, error) {
+	rules, err := v.GetRulesWithContext(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	var tasks = make(map[string]bool)
+	handles := make([]*TaskHandle, 0, len(rules))
 	for _, rawRule := range rules {
 		rule := rawRule.(map[string]interface{})
 		ruleID := rule["id"].(string)
-		jsonStr, err := v.client.Delete("firewall_vpc/delete_rule", map[string]string{"id": ruleID})
-		var task Task
-		json.Unmarshal([]byte(jsonStr), &task)
+		handle, err := v.client.LongDeleteTaskAsync(ctx, "firewall_vpc/delete_rule", ruleID, nil, ShortTimeSettings, opts...)
 		if err != nil {
-			return fmt.Errorf("Error when delete rule id %s: %+v", ruleID, err)
-		}
-		tasks[task.TaskID] = false
-	}
-	for taskID, finished := range tasks {
-		if !finished {
-			_, err = v.client.waitForTaskFinished(taskID, ShortTimeSettings)
-			if err != nil {
-				return fmt.Errorf("Error when delete rule, task id %s, %+v", taskID, err)
-			}
+			return handles, fmt.Errorf("Error when delete rule id %s: %+v", ruleID, err)
 		}
+		handles = append(handles, handle)
 	}
-	return nil
+	return handles, nil
 }
 
 func (v *firewallvpc) ValidateRules(inboundRules string, outboundRules string) ([]string, error) {
-	jsonStr, err := v.client.Post("firewall_vpc/validate_rules", map[string]interface{}{"inbound_rules": inboundRules, "outbound_rules": outboundRules})
+	return v.ValidateRulesWithContext(context.Background(), inboundRules, outboundRules)
+}
+
+func (v *firewallvpc) ValidateRulesWithContext(ctx context.Context, inboundRules string, outboundRules string) ([]string, error) {
+	// validation changes nothing, it is sent in dry-run mode too
+	jsonStr, err := v.client.PostWithContext(withReadOnly(ctx), "firewall_vpc/validate_rules", map[string]interface{}{"inbound_rules": inboundRules, "outbound_rules": outboundRules})
 	var errors []string
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &errors)
@@ -1,14 +1,20 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
 )
 
 // FloatingIPService interface
 type FloatingIPService interface {
 	Get(id string) (FloatingIP, error)
-	Create(vpcID string) (OrderResponse, TaskStatus, error)
-	Delete(id string) (TaskStatus, error)
+	GetWithContext(ctx context.Context, id string) (FloatingIP, error)
+	Create(vpcID string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateWithContext(ctx context.Context, vpcID string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateAsync(ctx context.Context, vpcID string, opts ...CallOption) (*TaskHandle, error)
+	Delete(id string, opts ...CallOption) (TaskStatus, error)
+	DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
 }
 
 // FloatingIP object
@@ -16,6 +22,7 @@ type FloatingIP struct {
 	ID                    string `json:"id"`
 	IPAddress             string `json:"ipaddress"`
 	RegionName            string `json:"zonename"`
+	RegionID              string `json:"zoneid"`
 	IsSourceNat           bool   `json:"issourcenat"`
 	IsStaticNat           bool   `json:"isstaticnat"`
 	AssociatedNetworkID   string `json:"associatednetworkid"`
@@ -23,6 +30,7 @@ type FloatingIP struct {
 	NetworkID             string `json:"networkid"`
 	State                 string `json:"state"`
 	VPCID                 string `json:"vpcid"`
+	Location              Region `json:"-"` // Region of the ip, from RegionName and RegionID
 }
 
 type floatingIP struct {
@@ -31,7 +39,12 @@ type floatingIP struct {
 
 // Get FloatingIP detail
 func (v *floatingIP) Get(id string) (FloatingIP, error) {
-	jsonStr, err := v.client.Get("floatingip/info", map[string]string{"id": id})
+	return v.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (v *floatingIP) GetWithContext(ctx context.Context, id string) (FloatingIP, error) {
+	jsonStr, err := v.client.GetWithContext(ctx, "floatingip/info", map[string]string{"id": id})
 	var floatingIP FloatingIP
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &floatingIP)
@@ -40,9 +53,30 @@ func (v *floatingIP) Get(id string) (FloatingIP, error) {
 }
 
 // Delete a FloatingIP
-func (v *floatingIP) Delete(id string) (TaskStatus, error) {
-	return v.client.LongDeleteTask("floatingip/delete", id, nil, ShortTimeSettings)
+func (v *floatingIP) Delete(id string, opts ...CallOption) (TaskStatus, error) {
+	return v.DeleteWithContext(context.Background(), id, opts...)
+}
+
+// DeleteWithContext same as Delete, cancellable through ctx
+func (v *floatingIP) DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.DeleteAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DeleteAsync same as Delete, returns once the task is submitted
+func (v *floatingIP) DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongDeleteTaskAsync(ctx, "floatingip/delete", id, nil, ShortTimeSettings, opts...)
+}
+func (v *floatingIP) Create(vpcID string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return v.CreateWithContext(context.Background(), vpcID, opts...)
 }
-func (v *floatingIP) Create(vpcID string) (OrderResponse, TaskStatus, error) {
-	return v.client.Order("floatingip/create", "", map[string]interface{}{"vpc_id": vpcID}, MediumTimeSettings)
+
+func (v *floatingIP) CreateWithContext(ctx context.Context, vpcID string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := v.CreateAsync(ctx, vpcID, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// CreateAsync same as Create, returns once the task is submitted
+func (v *floatingIP) CreateAsync(ctx context.Context, vpcID string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.OrderAsync(ctx, "floatingip/create", "", map[string]interface{}{"vpc_id": vpcID}, MediumTimeSettings, opts...)
 }
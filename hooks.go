@@ -0,0 +1,86 @@
+package gocmcapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes an api request, it is passed to request hooks. A
+// BeforeRequestHook may change Path, Params, Body and Header, e.g. to route
+// the request through a proxy. Params and Body are copies made for each
+// attempt. Metrics, spans and the circuit breaker keep the original path
+type RequestInfo struct {
+	Method  string                 // Http method
+	Path    string                 // Api path, e.g. server_action/resize
+	Params  map[string]string      // Query params, without the api key
+	Body    map[string]interface{} // Body of POST and PUT requests
+	Attempt int                    // 1 for the first attempt, incremented on each retry
+	Header  http.Header            // Extra headers
+}
+
+// ResponseInfo describes the result of an api request, it is passed to response hooks
+type ResponseInfo struct {
+	Request    *RequestInfo
+	StatusCode int           // Http status, 0 if no response was received
+	Duration   time.Duration // Time spent on the request
+	Body       string        // Raw response body
+	Err        error         // Transport error, api errors in the body are not detected yet
+}
+
+// BeforeRequestHook is called before each request attempt, returning an
+// error cancels the request with that error
+type BeforeRequestHook func(ctx context.Context, req *RequestInfo) error
+
+// AfterResponseHook is called after each request attempt, also when it failed
+type AfterResponseHook func(ctx context.Context, resp *ResponseInfo)
+
+// OnBeforeRequest adds a hook called before every request, hooks are called in
+// the order they are added. Hooks must be added before the client is used
+func (c *Client) OnBeforeRequest(hook BeforeRequestHook) *Client {
+	c.beforeRequestHooks = append(c.beforeRequestHooks, hook)
+	return c
+}
+
+// OnAfterResponse adds a hook called after every request, hooks are called in
+// the order they are added. Hooks must be added before the client is used
+func (c *Client) OnAfterResponse(hook AfterResponseHook) *Client {
+	c.afterResponseHooks = append(c.afterResponseHooks, hook)
+	return c
+}
+
+func (c *Client) runBeforeRequestHooks(ctx context.Context, req *RequestInfo) error {
+	for _, hook := range c.beforeRequestHooks {
+		if err := hook(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) runAfterResponseHooks(ctx context.Context, resp *ResponseInfo) {
+	for _, hook := range c.afterResponseHooks {
+		hook(ctx, resp)
+	}
+}
+
+// copyParams returns a copy of params that hooks can change, never nil
+func copyParams(params map[string]string) map[string]string {
+	copied := make(map[string]string, len(params))
+	for key, value := range params {
+		copied[key] = value
+	}
+	return copied
+}
+
+// copyBody returns a copy of body that hooks can change
+func copyBody(body map[string]interface{}) map[string]interface{} {
+	if body == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(body))
+	for key, value := range body {
+		copied[key] = value
+	}
+	return copied
+}
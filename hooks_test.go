@@ -0,0 +1,57 @@
+package gocmcapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBeforeRequestHookChangesRequest(t *testing.T) {
+	var gotPath, gotParam, gotHeader string
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotParam = r.URL.Query().Get("tenant")
+		gotHeader = r.Header.Get("X-Proxy")
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	c, err := NewClient("key", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.OnBeforeRequest(func(ctx context.Context, req *RequestInfo) error {
+		req.Path = "proxy/" + req.Path
+		req.Params["tenant"] = "t1"
+		if req.Body != nil {
+			req.Body["tenant"] = "t1"
+		}
+		req.Header.Set("X-Proxy", "1")
+		return nil
+	})
+
+	params := map[string]string{"id": "s1"}
+	if _, err := c.Get("server/info", params); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/proxy/server/info.json" || gotParam != "t1" || gotHeader != "1" {
+		t.Errorf("got path %s, tenant %q, header %q", gotPath, gotParam, gotHeader)
+	}
+	if _, ok := params["tenant"]; ok {
+		t.Error("hook changed the params of the caller")
+	}
+
+	body := map[string]interface{}{"name": "web"}
+	if _, err := c.Post("server/create", body); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/proxy/server/create.json" || gotBody["tenant"] != "t1" || gotBody["name"] != "web" {
+		t.Errorf("got path %s, body %v", gotPath, gotBody)
+	}
+	if _, ok := body["tenant"]; ok {
+		t.Error("hook changed the body of the caller")
+	}
+}
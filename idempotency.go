@@ -0,0 +1,312 @@
+package gocmcapi
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of
+// mutating requests
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// submissionTTL is how long submissions are remembered by idempotency key
+const submissionTTL = 24 * time.Hour
+
+var (
+	// ErrUnknownOutcome is matched by errors.Is when a mutating call got no
+	// answer, so the action may or may not have been done
+	ErrUnknownOutcome = errors.New("Outcome of the request is unknown")
+	// ErrIdempotencyKeyReused is matched by errors.Is when an idempotency key
+	// is used for another action or other params than its first call
+	ErrIdempotencyKeyReused = errors.New("Idempotency key was used for another request")
+)
+
+// UnknownOutcomeError is returned by LongTask and Order when the request got
+// no answer and no Reconciler found its resource. Retry the call with
+// WithIdempotencyKey(ctx, err.Key), it then reuses the key and reconciles first
+type UnknownOutcomeError struct {
+	Action string
+	Key    string // Idempotency key of the submission
+	Err    error
+}
+
+func (e *UnknownOutcomeError) Error() string {
+	return fmt.Sprintf("Error perform action %s: outcome unknown (idempotency key %s): %v", e.Action, e.Key, e.Err)
+}
+
+// Unwrap returns the request error
+func (e *UnknownOutcomeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrUnknownOutcome
+func (e *UnknownOutcomeError) Is(target error) bool {
+	return target == ErrUnknownOutcome
+}
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a context whose mutating calls are sent with
+// key. A key is for a single call, LongTask and Order fail with
+// ErrIdempotencyKeyReused when it is used again for another action or other
+// params. Calls without a key get a new one each time
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key of ctx, if any
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key
+}
+
+// NewIdempotencyKey returns a random uuid
+func NewIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Reconciler looks up the resource which an earlier submission of action,
+// whose outcome is unknown, may have created. It returns the resource id
+// and whether it was found
+type Reconciler func(ctx context.Context, action, key string, params map[string]interface{}) (id string, found bool, err error)
+
+// ReconcileByParam returns a Reconciler looking up the resource by the value
+// of param, e.g. the name of a server or a tag generated by the caller
+func ReconcileByParam(param string, lookup func(ctx context.Context, value string) (id string, found bool, err error)) Reconciler {
+	return func(ctx context.Context, action, key string, params map[string]interface{}) (string, bool, error) {
+		value, ok := params[param].(string)
+		if !ok || value == "" {
+			return "", false, nil
+		}
+		return lookup(ctx, value)
+	}
+}
+
+// submission is a LongTask or Order call, by idempotency key
+type submission struct {
+	key         string
+	action      string
+	fingerprint string // Hash of the action and params
+	inFlight    bool
+	unknown     bool   // An earlier request got no answer
+	response    string // Response of the request, once answered
+	resultID    string // Id found by a Reconciler
+	updated     time.Time
+	elem        *list.Element
+}
+
+// submissions is a pointer in Client so clients returned by ForRegion
+// share it. Only submissions with a key given by the caller or an unknown
+// outcome are kept, for submissionTTL
+type submissions struct {
+	mu    sync.Mutex
+	byKey map[string]*submission
+	order *list.List // Submissions, least recently updated first
+}
+
+func newSubmissions() *submissions {
+	return &submissions{byKey: make(map[string]*submission), order: list.New()}
+}
+
+// fingerprint returns a hash of a call, params are not kept as they may
+// hold secrets
+func fingerprint(action string, params map[string]interface{}) string {
+	data, err := json.Marshal(params)
+	if err != nil {
+		data = []byte(fmt.Sprint(params))
+	}
+	sum := sha256.Sum256(append([]byte(action+" "), data...))
+	return hex.EncodeToString(sum[:])
+}
+
+// begin marks key in flight and returns a copy of its earlier submission
+func (s *submissions) begin(key, action, fingerprint string) (submission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.expire(now)
+	sub, ok := s.byKey[key]
+	if !ok {
+		sub = &submission{key: key, action: action, fingerprint: fingerprint}
+		sub.elem = s.order.PushBack(sub)
+		s.byKey[key] = sub
+	}
+	if sub.fingerprint != fingerprint {
+		if sub.action != action {
+			return submission{}, fmt.Errorf("%w: key %s was used for %s", ErrIdempotencyKeyReused, key, sub.action)
+		}
+		return submission{}, fmt.Errorf("%w: key %s was used for %s with other params", ErrIdempotencyKeyReused, key, action)
+	}
+	if sub.inFlight {
+		return submission{}, fmt.Errorf("Error idempotency key %s is already in flight", key)
+	}
+	prev := *sub
+	sub.inFlight = true
+	sub.updated = now
+	s.order.MoveToBack(sub.elem)
+	return prev, nil
+}
+
+// expire forgets the submissions not updated for submissionTTL, s.mu must
+// be held
+func (s *submissions) expire(now time.Time) {
+	for e := s.order.Front(); e != nil; e = s.order.Front() {
+		sub := e.Value.(*submission)
+		if sub.inFlight || now.Sub(sub.updated) <= submissionTTL {
+			return
+		}
+		s.order.Remove(e)
+		delete(s.byKey, sub.key)
+	}
+}
+
+// end records the outcome of key, keep false forgets key
+func (s *submissions) end(key string, outcome submission, keep bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.byKey[key]
+	if !ok {
+		return
+	}
+	if !keep {
+		s.order.Remove(sub.elem)
+		delete(s.byKey, key)
+		return
+	}
+	sub.inFlight = false
+	sub.unknown = outcome.unknown
+	sub.response = outcome.response
+	sub.resultID = outcome.resultID
+	sub.updated = time.Now()
+	s.order.MoveToBack(sub.elem)
+}
+
+// submit posts action once per idempotency key. A repeated key reuses the
+// earlier response, or when its outcome was unknown, asks the Reconciler of
+// action before posting again. A non empty id means the resource was found
+// by the Reconciler and nothing was posted
+func (c *Client) submit(ctx context.Context, action string, params map[string]interface{}) (response string, id string, err error) {
+	key := IdempotencyKeyFromContext(ctx)
+	// a generated key can only be reused through an UnknownOutcomeError
+	generated := key == ""
+	if generated {
+		key = NewIdempotencyKey()
+		ctx = WithIdempotencyKey(ctx, key)
+	}
+	prev, err := c.submissions.begin(key, action, fingerprint(action, params))
+	if err != nil {
+		return "", "", err
+	}
+	if prev.response != "" || prev.resultID != "" {
+		c.logger.Info("Reusing earlier submission", "action", action, "idempotency_key", key)
+		c.submissions.end(key, prev, true)
+		return prev.response, prev.resultID, nil
+	}
+	reconcile := c.reconcilers[action]
+	if prev.unknown && reconcile != nil {
+		if id, found := c.reconcile(ctx, reconcile, action, key, params); found {
+			c.submissions.end(key, submission{resultID: id}, !generated)
+			return "", id, nil
+		}
+	}
+
+	sent := &requestSent{}
+	response, err = c.PostWithContext(context.WithValue(ctx, requestSentKey{}, sent), action, params)
+	if err == nil {
+		c.submissions.end(key, submission{response: response}, !generated)
+		return response, "", nil
+	}
+	if !sent.wasSent() || !isUnknownOutcome(err) {
+		c.submissions.end(key, submission{}, false)
+		return response, "", err
+	}
+	if reconcile != nil && ctx.Err() == nil {
+		if id, found := c.reconcile(ctx, reconcile, action, key, params); found {
+			c.submissions.end(key, submission{resultID: id}, !generated)
+			return "", id, nil
+		}
+	}
+	if ctx.Err() != nil {
+		c.submissions.end(key, submission{unknown: true}, !generated)
+		return response, "", ctx.Err()
+	}
+	c.submissions.end(key, submission{unknown: true}, true)
+	return response, "", &UnknownOutcomeError{Action: action, Key: key, Err: err}
+}
+
+func (c *Client) reconcile(ctx context.Context, reconcile Reconciler, action, key string, params map[string]interface{}) (string, bool) {
+	id, found, err := reconcile(ctx, action, key, params)
+	if err != nil {
+		c.logger.Warn("Reconcile failed", "action", action, "idempotency_key", key, "error", err)
+		return "", false
+	}
+	if found {
+		c.logger.Info("Reconciled submission", "action", action, "idempotency_key", key, "id", id)
+	}
+	return id, found
+}
+
+// requestSentKey is the context key of the requestSent of a submission
+type requestSentKey struct{}
+
+// requestSent records whether a request of a submission was written to the
+// connection, an error before that, e.g. from a hook or while dialing, leaves
+// nothing to reconcile
+type requestSent struct {
+	sent int32
+}
+
+func (s *requestSent) mark() {
+	atomic.StoreInt32(&s.sent, 1)
+}
+
+func (s *requestSent) wasSent() bool {
+	return atomic.LoadInt32(&s.sent) == 1
+}
+
+// traceSent returns ctx marking its requestSent, if any, once the request
+// headers are written
+func traceSent(ctx context.Context) context.Context {
+	sent, ok := ctx.Value(requestSentKey{}).(*requestSent)
+	if !ok {
+		return ctx
+	}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{WroteHeaders: sent.mark})
+}
+
+// markSent marks the requestSent of ctx, for transports that answer without
+// running the httptrace hooks
+func markSent(ctx context.Context) {
+	if sent, ok := ctx.Value(requestSentKey{}).(*requestSent); ok {
+		sent.mark()
+	}
+}
+
+// isUnknownOutcome reports whether the request failing with err may still
+// have been done once it was sent, i.e. there was no answer or a server error
+func isUnknownOutcome(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package gocmcapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubmissionsExpire(t *testing.T) {
+	s := newSubmissions()
+	for _, key := range []string{"a", "b", "c"} {
+		if _, err := s.begin(key, "server/create", "f"); err != nil {
+			t.Fatal(err)
+		}
+		s.end(key, submission{response: "{}"}, true)
+	}
+	s.byKey["a"].updated = time.Now().Add(-submissionTTL - time.Minute)
+	s.byKey["b"].updated = time.Now().Add(-submissionTTL - time.Minute)
+
+	if _, err := s.begin("d", "server/create", "f"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.byKey["a"]; ok {
+		t.Error("a was not expired")
+	}
+	if _, ok := s.byKey["b"]; ok {
+		t.Error("b was not expired")
+	}
+	if _, ok := s.byKey["c"]; !ok {
+		t.Error("c was expired")
+	}
+	if s.order.Len() != len(s.byKey) {
+		t.Errorf("order has %d submissions, byKey %d", s.order.Len(), len(s.byKey))
+	}
+}
@@ -0,0 +1,155 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+// count returns how many requests to path the cloud served
+func count(cloud *fakecloud.Cloud, path string) int {
+	n := 0
+	for _, p := range cloud.Requests() {
+		if p == path {
+			n++
+		}
+	}
+	return n
+}
+
+func TestIdempotencyKeyReusedForOtherCall(t *testing.T) {
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud)
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+	ctx := gocmcapi.WithIdempotencyKey(context.Background(), "batch-1")
+
+	if _, err := c.Server.StopAsync(ctx, s.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Server.StartAsync(ctx, s.ID); !errors.Is(err, gocmcapi.ErrIdempotencyKeyReused) {
+		t.Errorf("StartAsync with the key of StopAsync: %v, want ErrIdempotencyKeyReused", err)
+	}
+	if _, err := c.Server.StopAsync(ctx, "other"); !errors.Is(err, gocmcapi.ErrIdempotencyKeyReused) {
+		t.Errorf("StopAsync of another server: %v, want ErrIdempotencyKeyReused", err)
+	}
+	if count(cloud, "server_action/start") != 0 {
+		t.Error("start was sent")
+	}
+
+	// the same call is answered with the earlier response
+	first, _ := c.Server.StopAsync(gocmcapi.WithIdempotencyKey(context.Background(), "batch-2"), s.ID)
+	again, err := c.Server.StopAsync(gocmcapi.WithIdempotencyKey(context.Background(), "batch-2"), s.ID)
+	if err != nil || again.TaskID != first.TaskID {
+		t.Errorf("repeated StopAsync = %v, %v, want task %s", again, err, first.TaskID)
+	}
+	if n := count(cloud, "server_action/stop"); n != 2 {
+		t.Errorf("%d stop requests sent, want 2", n)
+	}
+}
+
+func TestGeneratedIdempotencyKeysAreNotKept(t *testing.T) {
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud)
+	s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+	for i := 0; i < 50; i++ {
+		if _, err := c.Server.Stop(s.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := c.SubmissionCount(); n != 0 {
+		t.Errorf("%d submissions kept, want 0", n)
+	}
+}
+
+func TestDroppedConnectionIsReplayed(t *testing.T) {
+	cloud := fakecloud.New(fakecloud.WithIdempotency())
+	c := newFakeClient(t, cloud, gocmcapi.WithRetryPolicy(gocmcapi.NoRetryPolicy))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+	// open a keep-alive connection, net/http only replays on reused ones
+	if _, err := c.Server.Get(s.ID); err != nil {
+		t.Fatal(err)
+	}
+	cloud.InjectFault(fakecloud.Fault{Path: "server_action/stop", Drop: true, Times: 1})
+	if _, err := c.Server.Stop(s.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := cloud.Server(s.ID); got.State != "stopped" {
+		t.Errorf("state = %s, want stopped", got.State)
+	}
+}
+
+func TestRetryUnknownOutcomeWithKey(t *testing.T) {
+	cloud := fakecloud.New(fakecloud.WithIdempotency())
+	c := newFakeClient(t, cloud, gocmcapi.WithRetryPolicy(gocmcapi.NoRetryPolicy))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+	cloud.InjectFault(fakecloud.Fault{Path: "server_action/stop", Drop: true})
+	_, err := c.Server.Stop(s.ID)
+	var unknown *gocmcapi.UnknownOutcomeError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Stop: %v, want an UnknownOutcomeError", err)
+	}
+	if c.SubmissionCount() != 1 {
+		t.Errorf("%d submissions kept, want the unknown one", c.SubmissionCount())
+	}
+
+	cloud.ClearFaults()
+	ctx := gocmcapi.WithIdempotencyKey(context.Background(), unknown.Key)
+	if _, err := c.Server.StopWithContext(ctx, s.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := cloud.Server(s.ID); got.State != "stopped" {
+		t.Errorf("state = %s, want stopped", got.State)
+	}
+}
+
+func TestOnlySentRequestsHaveUnknownOutcome(t *testing.T) {
+	reconciled := 0
+	reconciler := gocmcapi.WithReconciler("server_action/stop", func(ctx context.Context, action, key string, params map[string]interface{}) (string, bool, error) {
+		reconciled++
+		return "", false, nil
+	})
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud, gocmcapi.WithRetryPolicy(gocmcapi.NoRetryPolicy), reconciler)
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+	errHook := errors.New("hook failed")
+	failing := true
+	c.OnBeforeRequest(func(ctx context.Context, req *gocmcapi.RequestInfo) error {
+		if failing {
+			return errHook
+		}
+		return nil
+	})
+	_, err := c.Server.Stop(s.ID)
+	if !errors.Is(err, errHook) || errors.Is(err, gocmcapi.ErrUnknownOutcome) {
+		t.Errorf("Stop with a failing hook: %v, want the hook error", err)
+	}
+
+	// nothing is listening, the request is never written
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+	refused, err := gocmcapi.NewClient("key", gocmcapi.WithBaseURL(ts.URL), gocmcapi.WithRetryPolicy(gocmcapi.NoRetryPolicy), reconciler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := refused.Server.Stop(s.ID); err == nil || errors.Is(err, gocmcapi.ErrUnknownOutcome) {
+		t.Errorf("Stop on a refused connection: %v, want a known failure", err)
+	}
+	if reconciled != 0 || c.SubmissionCount() != 0 || refused.SubmissionCount() != 0 {
+		t.Errorf("%d reconciles and %d+%d submissions kept for requests never sent, want none", reconciled, c.SubmissionCount(), refused.SubmissionCount())
+	}
+
+	failing = false
+	cloud.InjectFault(fakecloud.Fault{Path: "server_action/stop", StatusCode: http.StatusInternalServerError})
+	if _, err := c.Server.Stop(s.ID); !errors.Is(err, gocmcapi.ErrUnknownOutcome) {
+		t.Errorf("Stop answered with a server error: %v, want ErrUnknownOutcome", err)
+	}
+	if reconciled != 1 {
+		t.Errorf("%d reconciles after a server error, want 1", reconciled)
+	}
+}
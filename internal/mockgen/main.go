@@ -0,0 +1,279 @@
+// Command mockgen generates the programmable fakes of the mocks package from
+// the service interfaces of gocmcapi, run it with go generate ./mocks
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const pkgName = "gocmcapi"
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type service struct {
+	name    string
+	methods []method
+}
+
+func main() {
+	src := flag.String("src", "..", "directory of the gocmcapi package")
+	out := flag.String("out", "mocks_gen.go", "output file")
+	flag.Parse()
+
+	services, err := parseServices(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	code, err := generate(services)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, code, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseServices returns the interfaces named *Service of the package in dir
+func parseServices(dir string) ([]service, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs[pkgName]
+	if !ok {
+		return nil, fmt.Errorf("package %s not found in %s", pkgName, dir)
+	}
+
+	var services []service
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				iface, ok := ts.Type.(*ast.InterfaceType)
+				if !ok || !strings.HasSuffix(ts.Name.Name, "Service") {
+					continue
+				}
+				s := service{name: ts.Name.Name}
+				for _, field := range iface.Methods.List {
+					fn, ok := field.Type.(*ast.FuncType)
+					if !ok {
+						return nil, fmt.Errorf("%s: embedded interfaces are not supported", ts.Name.Name)
+					}
+					s.methods = append(s.methods, newMethod(field.Names[0].Name, fn))
+				}
+				services = append(services, s)
+			}
+		}
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].name < services[j].name })
+	return services, nil
+}
+
+func newMethod(name string, fn *ast.FuncType) method {
+	m := method{name: name}
+	for _, field := range fn.Params.List {
+		typ := field.Type
+		variadic := false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			typ = ellipsis.Elt
+			variadic = true
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("a%d", len(m.params)))}
+		}
+		for _, n := range names {
+			m.params = append(m.params, param{name: n.Name, typ: typeString(typ), variadic: variadic})
+		}
+	}
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				m.results = append(m.results, typeString(field.Type))
+			}
+		}
+	}
+	return m
+}
+
+// typeString prints a type expression, qualifying the exported types of gocmcapi
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if unicode.IsUpper(rune(t.Name[0])) {
+			return pkgName + "." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.RECV:
+			return "<-chan " + typeString(t.Value)
+		case ast.SEND:
+			return "chan<- " + typeString(t.Value)
+		}
+		return "chan " + typeString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt)
+	case *ast.InterfaceType:
+		if len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+	case *ast.FuncType:
+		var params, results []string
+		for _, p := range newMethod("", t).params {
+			if p.variadic {
+				params = append(params, "..."+p.typ)
+			} else {
+				params = append(params, p.typ)
+			}
+		}
+		results = newMethod("", t).results
+		s := "func(" + strings.Join(params, ", ") + ")"
+		if len(results) == 1 {
+			s += " " + results[0]
+		} else if len(results) > 1 {
+			s += " (" + strings.Join(results, ", ") + ")"
+		}
+		return s
+	}
+	log.Fatalf("unsupported type %T", expr)
+	return ""
+}
+
+func (m method) signature() (params, results string) {
+	var ps []string
+	for _, p := range m.params {
+		if p.variadic {
+			ps = append(ps, p.name+" ..."+p.typ)
+		} else {
+			ps = append(ps, p.name+" "+p.typ)
+		}
+	}
+	params = strings.Join(ps, ", ")
+	switch len(m.results) {
+	case 0:
+	case 1:
+		results = " " + m.results[0]
+	default:
+		results = " (" + strings.Join(m.results, ", ") + ")"
+	}
+	return params, results
+}
+
+func generate(services []service) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by internal/mockgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package mocks\n\n")
+	fmt.Fprintf(&b, "import (\n\t\"context\"\n\n\t\"github.com/cmc-cloud/gocmcapi\"\n)\n\n")
+	usesContext := false
+
+	for _, s := range services {
+		fmt.Fprintf(&b, "// %s is a programmable fake of gocmcapi.%s\n", s.name, s.name)
+		fmt.Fprintf(&b, "type %s struct {\n\tMock\n\n", s.name)
+		for _, m := range s.methods {
+			params, results := m.signature()
+			fmt.Fprintf(&b, "\t%sFunc func(%s)%s\n", m.name, params, results)
+		}
+		fmt.Fprintf(&b, "}\n\n")
+		fmt.Fprintf(&b, "var _ gocmcapi.%s = (*%s)(nil)\n\n", s.name, s.name)
+
+		for _, m := range s.methods {
+			params, results := m.signature()
+			if strings.Contains(params, "context.") {
+				usesContext = true
+			}
+			var names, args []string
+			variadic := ""
+			for _, p := range m.params {
+				if p.variadic {
+					variadic = p.name
+					args = append(args, p.name+"...")
+				} else {
+					names = append(names, p.name)
+					args = append(args, p.name)
+				}
+			}
+			fmt.Fprintf(&b, "// %s records the call and runs %sFunc\n", m.name, m.name)
+			fmt.Fprintf(&b, "func (m *%s) %s(%s)%s {\n", s.name, m.name, params, results)
+			if variadic != "" {
+				// record the variadic arguments one by one, so calls without
+				// them match the expected arguments of the other params
+				fmt.Fprintf(&b, "\targs := []interface{}{%s}\n", strings.Join(names, ", "))
+				fmt.Fprintf(&b, "\tfor _, arg := range %s {\n\t\targs = append(args, arg)\n\t}\n", variadic)
+				fmt.Fprintf(&b, "\tm.record(%q, args...)\n", m.name)
+			} else {
+				fmt.Fprintf(&b, "\tm.record(%q%s)\n", m.name, prefixed(", ", names))
+			}
+			fmt.Fprintf(&b, "\tif m.%sFunc != nil {\n", m.name)
+			if len(m.results) > 0 {
+				fmt.Fprintf(&b, "\t\treturn m.%sFunc(%s)\n\t}\n", m.name, strings.Join(args, ", "))
+				var zeros []string
+				for i, r := range m.results {
+					if r == "error" {
+						zeros = append(zeros, fmt.Sprintf("notStubbed(%q)", s.name+"."+m.name))
+						continue
+					}
+					fmt.Fprintf(&b, "\tvar r%d %s\n", i, r)
+					zeros = append(zeros, fmt.Sprintf("r%d", i))
+				}
+				fmt.Fprintf(&b, "\treturn %s\n}\n\n", strings.Join(zeros, ", "))
+			} else {
+				fmt.Fprintf(&b, "\t\tm.%sFunc(%s)\n\t}\n}\n\n", m.name, strings.Join(args, ", "))
+			}
+		}
+	}
+
+	code := b.Bytes()
+	if !usesContext {
+		code = bytes.Replace(code, []byte("\t\"context\"\n\n"), nil, 1)
+	}
+	return format.Source(code)
+}
+
+func prefixed(prefix string, names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return prefix + strings.Join(names, ", ")
+}
@@ -0,0 +1,211 @@
+package gocmcapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JournalEntry is a submitted task which has not finished yet
+type JournalEntry struct {
+	TaskID       string       `json:"task_id"`
+	Command      string       `json:"command"`               // Action which started the task, e.g. snapshot/create
+	ResourceID   string       `json:"resource_id,omitempty"` // Id the action was called with, if any
+	TimeSettings TimeSettings `json:"time_settings"`
+	Submitted    time.Time    `json:"submitted"`
+}
+
+// TaskJournal records the tasks submitted by a client until they are DONE
+// or ERROR, so a new process can resume waiting for them, see
+// WithTaskJournal and Client.ResumeTasks
+type TaskJournal interface {
+	Add(entry JournalEntry) error
+	Remove(taskID string) error
+	// List returns the unfinished tasks, oldest first
+	List() ([]JournalEntry, error)
+}
+
+// MemoryJournal is a TaskJournal kept in memory
+type MemoryJournal struct {
+	mu      sync.Mutex
+	entries map[string]JournalEntry
+}
+
+// NewMemoryJournal creates an empty MemoryJournal
+func NewMemoryJournal() *MemoryJournal {
+	return &MemoryJournal{entries: make(map[string]JournalEntry)}
+}
+
+// Add records a task
+func (j *MemoryJournal) Add(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries[entry.TaskID] = entry
+	return nil
+}
+
+// Remove forgets a task
+func (j *MemoryJournal) Remove(taskID string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.entries, taskID)
+	return nil
+}
+
+// List returns the unfinished tasks, oldest first
+func (j *MemoryJournal) List() ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return sortedEntries(j.entries), nil
+}
+
+// FileJournal is a TaskJournal saved as a json file, the file is replaced
+// atomically on every change
+type FileJournal struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]JournalEntry
+}
+
+// NewFileJournal opens the journal at path, a missing file is an empty journal
+func NewFileJournal(path string) (*FileJournal, error) {
+	j := &FileJournal{path: path, entries: make(map[string]JournalEntry)}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []JournalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("Error read task journal %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		j.entries[entry.TaskID] = entry
+	}
+	return j, nil
+}
+
+// Add records a task
+func (j *FileJournal) Add(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries[entry.TaskID] = entry
+	return j.save()
+}
+
+// Remove forgets a task
+func (j *FileJournal) Remove(taskID string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.entries[taskID]; !ok {
+		return nil
+	}
+	delete(j.entries, taskID)
+	return j.save()
+}
+
+// List returns the unfinished tasks, oldest first
+func (j *FileJournal) List() ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return sortedEntries(j.entries), nil
+}
+
+// save writes the entries to a temporary file renamed over the journal, j.mu
+// must be held
+func (j *FileJournal) save() error {
+	data, err := json.MarshalIndent(sortedEntries(j.entries), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(j.path), filepath.Base(j.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), j.path)
+}
+
+func sortedEntries(byID map[string]JournalEntry) []JournalEntry {
+	entries := make([]JournalEntry, 0, len(byID))
+	for _, entry := range byID {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, k int) bool {
+		if entries[i].Submitted.Equal(entries[k].Submitted) {
+			return entries[i].TaskID < entries[k].TaskID
+		}
+		return entries[i].Submitted.Before(entries[k].Submitted)
+	})
+	return entries
+}
+
+// journalAdd records a submitted task in the journal, if any
+func (c *Client) journalAdd(handle *TaskHandle, resourceID string) {
+	if c.journal == nil || handle.TaskID == "" {
+		return
+	}
+	entry := JournalEntry{
+		TaskID:       handle.TaskID,
+		Command:      handle.Action,
+		ResourceID:   resourceID,
+		TimeSettings: handle.timeSettings,
+		Submitted:    time.Now(),
+	}
+	if err := c.journal.Add(entry); err != nil {
+		c.logger.Warn("Error add task to journal", "task_id", handle.TaskID, "error", err)
+	}
+}
+
+// journalRemove forgets a task which is DONE or ERROR
+func (c *Client) journalRemove(taskID string) {
+	if c.journal == nil || taskID == "" {
+		return
+	}
+	if err := c.journal.Remove(taskID); err != nil {
+		c.logger.Warn("Error remove task from journal", "task_id", taskID, "error", err)
+	}
+}
+
+// WaitForTask waits for a task submitted earlier, e.g. by another process
+func (c *Client) WaitForTask(ctx context.Context, taskID string, timeSettings TimeSettings) (TaskStatus, error) {
+	return c.ResumeTask(taskID, timeSettings).Wait(ctx)
+}
+
+// ResumeTasks returns handles for the unfinished tasks of the journal, see
+// WithTaskJournal
+func (c *Client) ResumeTasks() ([]*TaskHandle, error) {
+	if c.journal == nil {
+		return nil, nil
+	}
+	entries, err := c.journal.List()
+	if err != nil {
+		return nil, err
+	}
+	handles := make([]*TaskHandle, 0, len(entries))
+	for _, entry := range entries {
+		handle := newTaskHandle(c, entry.Command, entry.TaskID, entry.TimeSettings)
+		if entry.ResourceID != "" {
+			handle.ids = []string{entry.ResourceID}
+		}
+		handles = append(handles, handle)
+	}
+	return handles, nil
+}
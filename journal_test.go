@@ -0,0 +1,66 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+func TestFileJournalResumeTasks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	cloud := fakecloud.New()
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+	journal, err := gocmcapi.NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newFakeClient(t, cloud, gocmcapi.WithTaskJournal(journal))
+	handle, err := c.Server.StopAsync(context.Background(), s.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a new process reads the journal and resumes the wait
+	journal, err = gocmcapi.NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = newFakeClient(t, cloud, gocmcapi.WithTaskJournal(journal))
+	handles, err := c.ResumeTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handles) != 1 || handles[0].TaskID != handle.TaskID || handles[0].Action != "server_action/stop" {
+		t.Fatalf("ResumeTasks = %+v, want the stop task %s", handles, handle.TaskID)
+	}
+	if status, err := handles[0].Wait(context.Background()); err != nil || status.Status != "DONE" {
+		t.Fatalf("Wait = %+v, %v", status, err)
+	}
+
+	journal, err = gocmcapi.NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := journal.List(); len(entries) != 0 {
+		t.Errorf("journal still has %v after the task is done", entries)
+	}
+}
+
+func TestJournalKeepsFailedSubmissionsOut(t *testing.T) {
+	cloud := fakecloud.New()
+	cloud.InjectFault(fakecloud.Fault{Path: "server_action/stop", StatusCode: 500})
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+	journal := gocmcapi.NewMemoryJournal()
+	c := newFakeClient(t, cloud, gocmcapi.WithTaskJournal(journal))
+
+	if _, err := c.Server.StopAsync(context.Background(), s.ID); err == nil {
+		t.Fatal("StopAsync succeeded, want the injected error")
+	}
+	if entries, _ := journal.List(); len(entries) != 0 {
+		t.Errorf("journal has %v, want no entry for a failed submission", entries)
+	}
+}
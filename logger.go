@@ -0,0 +1,111 @@
+package gocmcapi
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger receives the log messages of a Client. keyvals are alternating
+// key/value pairs, e.g. Info("task finished", "task_id", id, "status", status).
+// A *slog.Logger can be used as a Logger directly
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// LogLevel is the severity of a log message
+type LogLevel int
+
+// Log levels, from the most verbose
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// NopLogger discards every message, it is the default logger of a Client
+type NopLogger struct{}
+
+// Debug discards the message
+func (NopLogger) Debug(msg string, keyvals ...interface{}) {}
+
+// Info discards the message
+func (NopLogger) Info(msg string, keyvals ...interface{}) {}
+
+// Warn discards the message
+func (NopLogger) Warn(msg string, keyvals ...interface{}) {}
+
+// Error discards the message
+func (NopLogger) Error(msg string, keyvals ...interface{}) {}
+
+// StdLogger writes messages of at least MinLevel to a standard library logger,
+// formatted as "[LEVEL] msg key=value ..."
+type StdLogger struct {
+	Logger   *log.Logger // nil writes to the standard logger of the log package
+	MinLevel LogLevel
+}
+
+// NewStdLogger creates a StdLogger writing to l
+func NewStdLogger(l *log.Logger, minLevel LogLevel) *StdLogger {
+	return &StdLogger{Logger: l, MinLevel: minLevel}
+}
+
+// Debug logs a debug message
+func (l *StdLogger) Debug(msg string, keyvals ...interface{}) {
+	l.output(0, LogLevelDebug, msg, keyvals)
+}
+
+// Info logs an info message
+func (l *StdLogger) Info(msg string, keyvals ...interface{}) {
+	l.output(0, LogLevelInfo, msg, keyvals)
+}
+
+// Warn logs a warning
+func (l *StdLogger) Warn(msg string, keyvals ...interface{}) {
+	l.output(0, LogLevelWarn, msg, keyvals)
+}
+
+// Error logs an error
+func (l *StdLogger) Error(msg string, keyvals ...interface{}) {
+	l.output(0, LogLevelError, msg, keyvals)
+}
+
+// output writes a message, skip is the number of frames between the caller
+// and the Debug, Info... method, so log.Lshortfile reports the caller
+func (l *StdLogger) output(skip int, level LogLevel, msg string, keyvals []interface{}) {
+	if level < l.MinLevel {
+		return
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[%s] %s", level, msg)
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 < len(keyvals) {
+			fmt.Fprintf(&sb, " %v=%v", keyvals[i], keyvals[i+1])
+		} else {
+			fmt.Fprintf(&sb, " %v=<missing>", keyvals[i])
+		}
+	}
+	if l.Logger != nil {
+		l.Logger.Output(3+skip, sb.String())
+	} else {
+		log.Output(3+skip, sb.String())
+	}
+}
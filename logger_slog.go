@@ -0,0 +1,17 @@
+//go:build go1.21
+// +build go1.21
+
+package gocmcapi
+
+import "log/slog"
+
+// a *slog.Logger is a Logger, this fails to compile if Logger changes
+var _ Logger = (*slog.Logger)(nil)
+
+// NewSlogLogger returns l as a Logger, a nil l uses slog.Default()
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		return slog.Default()
+	}
+	return l
+}
@@ -0,0 +1,78 @@
+package gocmcapi
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0), LogLevelInfo)
+	logger.Debug("hidden", "k", 1)
+	logger.Info("task finished", "task_id", "t1", "status", "DONE")
+	logger.Warn("odd", "key")
+	logger.Error("failed", "error", "boom")
+
+	want := "[INFO] task finished task_id=t1 status=DONE\n" +
+		"[WARN] odd key=<missing>\n" +
+		"[ERROR] failed error=boom\n"
+	if buf.String() != want {
+		t.Errorf("logged\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	var logger Logger = NopLogger{}
+	logger.Debug("msg", "k", "v")
+	logger.Info("msg")
+	logger.Warn("msg", "k")
+	logger.Error("msg", "k", nil)
+}
+
+// recordingLogger keeps the messages it receives
+type recordingLogger struct {
+	NopLogger
+	lines []string
+}
+
+func (l *recordingLogger) Warn(msg string, keyvals ...interface{}) {
+	line := msg
+	for _, v := range keyvals {
+		line += " " + toString(v)
+	}
+	l.lines = append(l.lines, line)
+}
+
+func toString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return "?"
+}
+
+func TestRedactingLoggerWrapsAnyLogger(t *testing.T) {
+	inner := &recordingLogger{}
+	logger := redactingLogger{logger: inner, secret: "s3cr3t-key"}
+	logger.Warn("url https://api/x?api_key=s3cr3t-key", "url", "q=s3cr3t-key", "password", "hunter2", "id", "s1")
+
+	want := "url https://api/x?api_key=REDACTED url q=REDACTED password REDACTED id s1"
+	if len(inner.lines) != 1 || inner.lines[0] != want {
+		t.Errorf("logged %q, want %q", inner.lines, want)
+	}
+}
+
+func TestRedactingLoggerReportsCaller(t *testing.T) {
+	var buf bytes.Buffer
+	std := NewStdLogger(log.New(&buf, "", log.Lshortfile), LogLevelDebug)
+	var logger Logger = redactingLogger{logger: std, secret: "key"}
+
+	logger.Info("wrapped")
+	std.Info("direct")
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if !strings.HasPrefix(line, "logger_test.go:") {
+			t.Errorf("logged %q, want the file of the caller", line)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gocmcapi
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TaskOutcome is how a task wait ended
+type TaskOutcome string
+
+// Task outcomes reported to Metrics
+const (
+	TaskOutcomeDone     TaskOutcome = "DONE"     // Task finished
+	TaskOutcomeError    TaskOutcome = "ERROR"    // Task finished with status ERROR
+	TaskOutcomeTimeout  TaskOutcome = "timeout"  // TimeSettings.Timeout exceeded
+	TaskOutcomeCanceled TaskOutcome = "canceled" // Context canceled
+	TaskOutcomeUnknown  TaskOutcome = "unknown"  // Task status could not be polled
+)
+
+// Metrics records request and task metrics, see the prometheus module for a
+// Prometheus implementation. Implementations must be safe for concurrent use
+type Metrics interface {
+	// ObserveRequest is called after every http attempt, statusCode is 0 when
+	// no response was received
+	ObserveRequest(method, endpoint string, statusCode int, duration time.Duration)
+	// ObserveTaskWait is called when a task wait ends, command is the action
+	// which started the task, or TaskStatus.Command when it is unknown, and
+	// timeout is the TimeSettings timeout of the wait
+	ObserveTaskWait(command string, outcome TaskOutcome, duration, timeout time.Duration)
+	// IncOrderUnpaid is called when an order is not paid
+	IncOrderUnpaid(endpoint string)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(method, endpoint string, statusCode int, duration time.Duration) {}
+func (nopMetrics) ObserveTaskWait(command string, outcome TaskOutcome, duration, timeout time.Duration) {
+}
+func (nopMetrics) IncOrderUnpaid(endpoint string) {}
+
+// taskOutcome returns the outcome of a task wait which ended with err
+func taskOutcome(ctx context.Context, last TaskStatus, err error) TaskOutcome {
+	var timeoutErr *TimeoutError
+	switch {
+	case err == nil:
+		return TaskOutcomeDone
+	case ctx.Err() != nil:
+		return TaskOutcomeCanceled
+	case errors.As(err, &timeoutErr):
+		return TaskOutcomeTimeout
+	case last.Status == "ERROR":
+		return TaskOutcomeError
+	default:
+		return TaskOutcomeUnknown
+	}
+}
@@ -0,0 +1,155 @@
+// Package mocks provides programmable fakes of the gocmcapi service
+// interfaces. Each fake has a <Method>Func field per method, records every
+// call and offers assertions on them:
+//
+//	servers := &mocks.ServerService{
+//		GetFunc: func(id string) (gocmcapi.Server, error) {
+//			return gocmcapi.Server{ID: id, State: "running"}, nil
+//		},
+//	}
+//	client.Server = servers
+//	...
+//	servers.AssertCalled(t, "Get", "server-id")
+//
+// A method without stub returns zero values and ErrNotStubbed. The fakes are
+// generated from the interfaces, run go generate ./mocks after changing them.
+package mocks
+
+//go:generate go run ../internal/mockgen -src .. -out mocks_gen.go
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrNotStubbed is returned by a method whose <Method>Func is nil
+var ErrNotStubbed = errors.New("method is not stubbed")
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrNotStubbed)
+}
+
+// Anything matches any argument in assertions, e.g. a context
+var Anything = anything{}
+
+type anything struct{}
+
+// Call is a recorded method call
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// TestingT is the part of *testing.T used by assertions
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Mock records the calls of a fake, it is embedded in every fake
+type Mock struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (m *Mock) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns every recorded call in order
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the recorded calls of a method
+func (m *Mock) CallsTo(method string) []Call {
+	var calls []Call
+	for _, c := range m.Calls() {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// AssertCalled checks that method was called at least once with args,
+// Anything matches any argument
+func (m *Mock) AssertCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	calls := m.CallsTo(method)
+	for _, c := range calls {
+		if argsMatch(args, c.Args) {
+			return true
+		}
+	}
+	if len(calls) == 0 {
+		t.Errorf("expected call %s(%s), but it was never called", method, formatArgs(args))
+		return false
+	}
+	var got []string
+	for _, c := range calls {
+		got = append(got, fmt.Sprintf("%s(%s)", method, formatArgs(c.Args)))
+	}
+	t.Errorf("expected call %s(%s), got %v", method, formatArgs(args), got)
+	return false
+}
+
+// AssertNotCalled checks that method was never called
+func (m *Mock) AssertNotCalled(t TestingT, method string) bool {
+	t.Helper()
+	if calls := m.CallsTo(method); len(calls) > 0 {
+		t.Errorf("expected no call to %s, got %d", method, len(calls))
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls checks that method was called n times
+func (m *Mock) AssertNumberOfCalls(t TestingT, method string, n int) bool {
+	t.Helper()
+	if calls := m.CallsTo(method); len(calls) != n {
+		t.Errorf("expected %d calls to %s, got %d", n, method, len(calls))
+		return false
+	}
+	return true
+}
+
+func argsMatch(expected, actual []interface{}) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		if expected[i] == Anything {
+			continue
+		}
+		if !reflect.DeepEqual(expected[i], actual[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatArgs(args []interface{}) string {
+	s := make([]string, len(args))
+	for i, a := range args {
+		if a == Anything {
+			s[i] = "<anything>"
+		} else {
+			s[i] = fmt.Sprintf("%#v", a)
+		}
+	}
+	return fmt.Sprint(s)
+}
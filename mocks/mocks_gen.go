@@ -0,0 +1,2292 @@
+// Code generated by internal/mockgen; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/cmc-cloud/gocmcapi"
+)
+
+// FirewallDirectService is a programmable fake of gocmcapi.FirewallDirectService
+type FirewallDirectService struct {
+	Mock
+
+	GetFunc                  func(serverID string, ipAddress string) (gocmcapi.FirewallDirect, error)
+	GetWithContextFunc       func(ctx context.Context, serverID string, ipAddress string) (gocmcapi.FirewallDirect, error)
+	DeleteFunc               func(serverID string, ipAddress string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteWithContextFunc    func(ctx context.Context, serverID string, ipAddress string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteAsyncFunc          func(ctx context.Context, serverID string, ipAddress string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	SaveRulesFunc            func(erverID string, ipAddress string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	SaveRulesWithContextFunc func(ctx context.Context, erverID string, ipAddress string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	SaveRulesAsyncFunc       func(ctx context.Context, serverID string, ipAddress string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+}
+
+var _ gocmcapi.FirewallDirectService = (*FirewallDirectService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *FirewallDirectService) Get(serverID string, ipAddress string) (gocmcapi.FirewallDirect, error) {
+	m.record("Get", serverID, ipAddress)
+	if m.GetFunc != nil {
+		return m.GetFunc(serverID, ipAddress)
+	}
+	var r0 gocmcapi.FirewallDirect
+	return r0, notStubbed("FirewallDirectService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *FirewallDirectService) GetWithContext(ctx context.Context, serverID string, ipAddress string) (gocmcapi.FirewallDirect, error) {
+	m.record("GetWithContext", ctx, serverID, ipAddress)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, serverID, ipAddress)
+	}
+	var r0 gocmcapi.FirewallDirect
+	return r0, notStubbed("FirewallDirectService.GetWithContext")
+}
+
+// Delete records the call and runs DeleteFunc
+func (m *FirewallDirectService) Delete(serverID string, ipAddress string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{serverID, ipAddress}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Delete", args...)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(serverID, ipAddress, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallDirectService.Delete")
+}
+
+// DeleteWithContext records the call and runs DeleteWithContextFunc
+func (m *FirewallDirectService) DeleteWithContext(ctx context.Context, serverID string, ipAddress string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, serverID, ipAddress}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteWithContext", args...)
+	if m.DeleteWithContextFunc != nil {
+		return m.DeleteWithContextFunc(ctx, serverID, ipAddress, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallDirectService.DeleteWithContext")
+}
+
+// DeleteAsync records the call and runs DeleteAsyncFunc
+func (m *FirewallDirectService) DeleteAsync(ctx context.Context, serverID string, ipAddress string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, serverID, ipAddress}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAsync", args...)
+	if m.DeleteAsyncFunc != nil {
+		return m.DeleteAsyncFunc(ctx, serverID, ipAddress, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallDirectService.DeleteAsync")
+}
+
+// SaveRules records the call and runs SaveRulesFunc
+func (m *FirewallDirectService) SaveRules(erverID string, ipAddress string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{erverID, ipAddress, inboundRules, outboundRules}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("SaveRules", args...)
+	if m.SaveRulesFunc != nil {
+		return m.SaveRulesFunc(erverID, ipAddress, inboundRules, outboundRules, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallDirectService.SaveRules")
+}
+
+// SaveRulesWithContext records the call and runs SaveRulesWithContextFunc
+func (m *FirewallDirectService) SaveRulesWithContext(ctx context.Context, erverID string, ipAddress string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, erverID, ipAddress, inboundRules, outboundRules}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("SaveRulesWithContext", args...)
+	if m.SaveRulesWithContextFunc != nil {
+		return m.SaveRulesWithContextFunc(ctx, erverID, ipAddress, inboundRules, outboundRules, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallDirectService.SaveRulesWithContext")
+}
+
+// SaveRulesAsync records the call and runs SaveRulesAsyncFunc
+func (m *FirewallDirectService) SaveRulesAsync(ctx context.Context, serverID string, ipAddress string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, serverID, ipAddress, inboundRules, outboundRules}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("SaveRulesAsync", args...)
+	if m.SaveRulesAsyncFunc != nil {
+		return m.SaveRulesAsyncFunc(ctx, serverID, ipAddress, inboundRules, outboundRules, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallDirectService.SaveRulesAsync")
+}
+
+// FirewallVPCService is a programmable fake of gocmcapi.FirewallVPCService
+type FirewallVPCService struct {
+	Mock
+
+	GetFunc                       func(id string) (gocmcapi.FirewallVPC, error)
+	GetWithContextFunc            func(ctx context.Context, id string) (gocmcapi.FirewallVPC, error)
+	CreateFunc                    func(vpcID string, name string, description string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	CreateWithContextFunc         func(ctx context.Context, vpcID string, name string, description string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	CreateAsyncFunc               func(ctx context.Context, vpcID string, name string, description string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DeleteFunc                    func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteWithContextFunc         func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteAsyncFunc               func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	UpdateFunc                    func(id string, name string, description string, opts ...gocmcapi.CallOption) error
+	UpdateWithContextFunc         func(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) error
+	UpdateAsyncFunc               func(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DeleteAllRulesFunc            func(id string, opts ...gocmcapi.CallOption) error
+	DeleteAllRulesWithContextFunc func(ctx context.Context, id string, opts ...gocmcapi.CallOption) error
+	DeleteAllRulesAsyncFunc       func(ctx context.Context, id string, opts ...gocmcapi.CallOption) ([]*gocmcapi.TaskHandle, error)
+	CreateRuleFunc                func(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	CreateRuleWithContextFunc     func(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	CreateRuleAsyncFunc           func(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	UpdateRuleFunc                func(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	UpdateRuleWithContextFunc     func(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	UpdateRuleAsyncFunc           func(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	GetRulesFunc                  func(id string) ([]interface{}, error)
+	GetRulesWithContextFunc       func(ctx context.Context, id string) ([]interface{}, error)
+	ValidateRulesFunc             func(inboundRules string, outboundRules string) ([]string, error)
+	ValidateRulesWithContextFunc  func(ctx context.Context, inboundRules string, outboundRules string) ([]string, error)
+	SaveRulesFunc                 func(id string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	SaveRulesWithContextFunc      func(ctx context.Context, id string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	SaveRulesAsyncFunc            func(ctx context.Context, id string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+}
+
+var _ gocmcapi.FirewallVPCService = (*FirewallVPCService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *FirewallVPCService) Get(id string) (gocmcapi.FirewallVPC, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 gocmcapi.FirewallVPC
+	return r0, notStubbed("FirewallVPCService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *FirewallVPCService) GetWithContext(ctx context.Context, id string) (gocmcapi.FirewallVPC, error) {
+	m.record("GetWithContext", ctx, id)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, id)
+	}
+	var r0 gocmcapi.FirewallVPC
+	return r0, notStubbed("FirewallVPCService.GetWithContext")
+}
+
+// Create records the call and runs CreateFunc
+func (m *FirewallVPCService) Create(vpcID string, name string, description string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{vpcID, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Create", args...)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(vpcID, name, description, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.Create")
+}
+
+// CreateWithContext records the call and runs CreateWithContextFunc
+func (m *FirewallVPCService) CreateWithContext(ctx context.Context, vpcID string, name string, description string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, vpcID, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateWithContext", args...)
+	if m.CreateWithContextFunc != nil {
+		return m.CreateWithContextFunc(ctx, vpcID, name, description, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.CreateWithContext")
+}
+
+// CreateAsync records the call and runs CreateAsyncFunc
+func (m *FirewallVPCService) CreateAsync(ctx context.Context, vpcID string, name string, description string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, vpcID, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateAsync", args...)
+	if m.CreateAsyncFunc != nil {
+		return m.CreateAsyncFunc(ctx, vpcID, name, description, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallVPCService.CreateAsync")
+}
+
+// Delete records the call and runs DeleteFunc
+func (m *FirewallVPCService) Delete(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Delete", args...)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.Delete")
+}
+
+// DeleteWithContext records the call and runs DeleteWithContextFunc
+func (m *FirewallVPCService) DeleteWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteWithContext", args...)
+	if m.DeleteWithContextFunc != nil {
+		return m.DeleteWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.DeleteWithContext")
+}
+
+// DeleteAsync records the call and runs DeleteAsyncFunc
+func (m *FirewallVPCService) DeleteAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAsync", args...)
+	if m.DeleteAsyncFunc != nil {
+		return m.DeleteAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallVPCService.DeleteAsync")
+}
+
+// Update records the call and runs UpdateFunc
+func (m *FirewallVPCService) Update(id string, name string, description string, opts ...gocmcapi.CallOption) error {
+	args := []interface{}{id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Update", args...)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(id, name, description, opts...)
+	}
+	return notStubbed("FirewallVPCService.Update")
+}
+
+// UpdateWithContext records the call and runs UpdateWithContextFunc
+func (m *FirewallVPCService) UpdateWithContext(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) error {
+	args := []interface{}{ctx, id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateWithContext", args...)
+	if m.UpdateWithContextFunc != nil {
+		return m.UpdateWithContextFunc(ctx, id, name, description, opts...)
+	}
+	return notStubbed("FirewallVPCService.UpdateWithContext")
+}
+
+// UpdateAsync records the call and runs UpdateAsyncFunc
+func (m *FirewallVPCService) UpdateAsync(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateAsync", args...)
+	if m.UpdateAsyncFunc != nil {
+		return m.UpdateAsyncFunc(ctx, id, name, description, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallVPCService.UpdateAsync")
+}
+
+// DeleteAllRules records the call and runs DeleteAllRulesFunc
+func (m *FirewallVPCService) DeleteAllRules(id string, opts ...gocmcapi.CallOption) error {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAllRules", args...)
+	if m.DeleteAllRulesFunc != nil {
+		return m.DeleteAllRulesFunc(id, opts...)
+	}
+	return notStubbed("FirewallVPCService.DeleteAllRules")
+}
+
+// DeleteAllRulesWithContext records the call and runs DeleteAllRulesWithContextFunc
+func (m *FirewallVPCService) DeleteAllRulesWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) error {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAllRulesWithContext", args...)
+	if m.DeleteAllRulesWithContextFunc != nil {
+		return m.DeleteAllRulesWithContextFunc(ctx, id, opts...)
+	}
+	return notStubbed("FirewallVPCService.DeleteAllRulesWithContext")
+}
+
+// DeleteAllRulesAsync records the call and runs DeleteAllRulesAsyncFunc
+func (m *FirewallVPCService) DeleteAllRulesAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) ([]*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAllRulesAsync", args...)
+	if m.DeleteAllRulesAsyncFunc != nil {
+		return m.DeleteAllRulesAsyncFunc(ctx, id, opts...)
+	}
+	var r0 []*gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallVPCService.DeleteAllRulesAsync")
+}
+
+// CreateRule records the call and runs CreateRuleFunc
+func (m *FirewallVPCService) CreateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, number, cidrs, action, protocol, ruleType, portRange}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateRule", args...)
+	if m.CreateRuleFunc != nil {
+		return m.CreateRuleFunc(id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.CreateRule")
+}
+
+// CreateRuleWithContext records the call and runs CreateRuleWithContextFunc
+func (m *FirewallVPCService) CreateRuleWithContext(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, number, cidrs, action, protocol, ruleType, portRange}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateRuleWithContext", args...)
+	if m.CreateRuleWithContextFunc != nil {
+		return m.CreateRuleWithContextFunc(ctx, id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.CreateRuleWithContext")
+}
+
+// CreateRuleAsync records the call and runs CreateRuleAsyncFunc
+func (m *FirewallVPCService) CreateRuleAsync(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, number, cidrs, action, protocol, ruleType, portRange}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateRuleAsync", args...)
+	if m.CreateRuleAsyncFunc != nil {
+		return m.CreateRuleAsyncFunc(ctx, id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallVPCService.CreateRuleAsync")
+}
+
+// UpdateRule records the call and runs UpdateRuleFunc
+func (m *FirewallVPCService) UpdateRule(id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, number, cidrs, action, protocol, ruleType, portRange}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateRule", args...)
+	if m.UpdateRuleFunc != nil {
+		return m.UpdateRuleFunc(id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.UpdateRule")
+}
+
+// UpdateRuleWithContext records the call and runs UpdateRuleWithContextFunc
+func (m *FirewallVPCService) UpdateRuleWithContext(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, number, cidrs, action, protocol, ruleType, portRange}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateRuleWithContext", args...)
+	if m.UpdateRuleWithContextFunc != nil {
+		return m.UpdateRuleWithContextFunc(ctx, id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.UpdateRuleWithContext")
+}
+
+// UpdateRuleAsync records the call and runs UpdateRuleAsyncFunc
+func (m *FirewallVPCService) UpdateRuleAsync(ctx context.Context, id string, number int, cidrs string, action string, protocol string, ruleType string, portRange string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, number, cidrs, action, protocol, ruleType, portRange}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateRuleAsync", args...)
+	if m.UpdateRuleAsyncFunc != nil {
+		return m.UpdateRuleAsyncFunc(ctx, id, number, cidrs, action, protocol, ruleType, portRange, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallVPCService.UpdateRuleAsync")
+}
+
+// GetRules records the call and runs GetRulesFunc
+func (m *FirewallVPCService) GetRules(id string) ([]interface{}, error) {
+	m.record("GetRules", id)
+	if m.GetRulesFunc != nil {
+		return m.GetRulesFunc(id)
+	}
+	var r0 []interface{}
+	return r0, notStubbed("FirewallVPCService.GetRules")
+}
+
+// GetRulesWithContext records the call and runs GetRulesWithContextFunc
+func (m *FirewallVPCService) GetRulesWithContext(ctx context.Context, id string) ([]interface{}, error) {
+	m.record("GetRulesWithContext", ctx, id)
+	if m.GetRulesWithContextFunc != nil {
+		return m.GetRulesWithContextFunc(ctx, id)
+	}
+	var r0 []interface{}
+	return r0, notStubbed("FirewallVPCService.GetRulesWithContext")
+}
+
+// ValidateRules records the call and runs ValidateRulesFunc
+func (m *FirewallVPCService) ValidateRules(inboundRules string, outboundRules string) ([]string, error) {
+	m.record("ValidateRules", inboundRules, outboundRules)
+	if m.ValidateRulesFunc != nil {
+		return m.ValidateRulesFunc(inboundRules, outboundRules)
+	}
+	var r0 []string
+	return r0, notStubbed("FirewallVPCService.ValidateRules")
+}
+
+// ValidateRulesWithContext records the call and runs ValidateRulesWithContextFunc
+func (m *FirewallVPCService) ValidateRulesWithContext(ctx context.Context, inboundRules string, outboundRules string) ([]string, error) {
+	m.record("ValidateRulesWithContext", ctx, inboundRules, outboundRules)
+	if m.ValidateRulesWithContextFunc != nil {
+		return m.ValidateRulesWithContextFunc(ctx, inboundRules, outboundRules)
+	}
+	var r0 []string
+	return r0, notStubbed("FirewallVPCService.ValidateRulesWithContext")
+}
+
+// SaveRules records the call and runs SaveRulesFunc
+func (m *FirewallVPCService) SaveRules(id string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, inboundRules, outboundRules}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("SaveRules", args...)
+	if m.SaveRulesFunc != nil {
+		return m.SaveRulesFunc(id, inboundRules, outboundRules, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.SaveRules")
+}
+
+// SaveRulesWithContext records the call and runs SaveRulesWithContextFunc
+func (m *FirewallVPCService) SaveRulesWithContext(ctx context.Context, id string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, inboundRules, outboundRules}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("SaveRulesWithContext", args...)
+	if m.SaveRulesWithContextFunc != nil {
+		return m.SaveRulesWithContextFunc(ctx, id, inboundRules, outboundRules, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FirewallVPCService.SaveRulesWithContext")
+}
+
+// SaveRulesAsync records the call and runs SaveRulesAsyncFunc
+func (m *FirewallVPCService) SaveRulesAsync(ctx context.Context, id string, inboundRules string, outboundRules string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, inboundRules, outboundRules}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("SaveRulesAsync", args...)
+	if m.SaveRulesAsyncFunc != nil {
+		return m.SaveRulesAsyncFunc(ctx, id, inboundRules, outboundRules, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FirewallVPCService.SaveRulesAsync")
+}
+
+// FloatingIPService is a programmable fake of gocmcapi.FloatingIPService
+type FloatingIPService struct {
+	Mock
+
+	GetFunc               func(id string) (gocmcapi.FloatingIP, error)
+	GetWithContextFunc    func(ctx context.Context, id string) (gocmcapi.FloatingIP, error)
+	CreateFunc            func(vpcID string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateWithContextFunc func(ctx context.Context, vpcID string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateAsyncFunc       func(ctx context.Context, vpcID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DeleteFunc            func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteWithContextFunc func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteAsyncFunc       func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+}
+
+var _ gocmcapi.FloatingIPService = (*FloatingIPService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *FloatingIPService) Get(id string) (gocmcapi.FloatingIP, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 gocmcapi.FloatingIP
+	return r0, notStubbed("FloatingIPService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *FloatingIPService) GetWithContext(ctx context.Context, id string) (gocmcapi.FloatingIP, error) {
+	m.record("GetWithContext", ctx, id)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, id)
+	}
+	var r0 gocmcapi.FloatingIP
+	return r0, notStubbed("FloatingIPService.GetWithContext")
+}
+
+// Create records the call and runs CreateFunc
+func (m *FloatingIPService) Create(vpcID string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{vpcID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Create", args...)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(vpcID, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("FloatingIPService.Create")
+}
+
+// CreateWithContext records the call and runs CreateWithContextFunc
+func (m *FloatingIPService) CreateWithContext(ctx context.Context, vpcID string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, vpcID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateWithContext", args...)
+	if m.CreateWithContextFunc != nil {
+		return m.CreateWithContextFunc(ctx, vpcID, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("FloatingIPService.CreateWithContext")
+}
+
+// CreateAsync records the call and runs CreateAsyncFunc
+func (m *FloatingIPService) CreateAsync(ctx context.Context, vpcID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, vpcID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateAsync", args...)
+	if m.CreateAsyncFunc != nil {
+		return m.CreateAsyncFunc(ctx, vpcID, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FloatingIPService.CreateAsync")
+}
+
+// Delete records the call and runs DeleteFunc
+func (m *FloatingIPService) Delete(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Delete", args...)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FloatingIPService.Delete")
+}
+
+// DeleteWithContext records the call and runs DeleteWithContextFunc
+func (m *FloatingIPService) DeleteWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteWithContext", args...)
+	if m.DeleteWithContextFunc != nil {
+		return m.DeleteWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("FloatingIPService.DeleteWithContext")
+}
+
+// DeleteAsync records the call and runs DeleteAsyncFunc
+func (m *FloatingIPService) DeleteAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAsync", args...)
+	if m.DeleteAsyncFunc != nil {
+		return m.DeleteAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("FloatingIPService.DeleteAsync")
+}
+
+// NetworkService is a programmable fake of gocmcapi.NetworkService
+type NetworkService struct {
+	Mock
+
+	GetFunc                         func(id string) (gocmcapi.Network, error)
+	GetWithContextFunc              func(ctx context.Context, id string) (gocmcapi.Network, error)
+	UpdateFunc                      func(id string, name string, description string, opts ...gocmcapi.CallOption) error
+	UpdateWithContextFunc           func(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) error
+	UpdateAsyncFunc                 func(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DeleteFunc                      func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteWithContextFunc           func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteAsyncFunc                 func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	ChangeFirewallFunc              func(id string, firewallID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	ChangeFirewallWithContextFunc   func(ctx context.Context, id string, firewallID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	ChangeFirewallAsyncFunc         func(ctx context.Context, id string, firewallID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	CreateVPCNetworkFunc            func(vpcID string, name string, description string, gateway string, netmask string, firewallID string) (gocmcapi.ResultResponse, error)
+	CreateVPCNetworkWithContextFunc func(ctx context.Context, vpcID string, name string, description string, gateway string, netmask string, firewallID string) (gocmcapi.ResultResponse, error)
+}
+
+var _ gocmcapi.NetworkService = (*NetworkService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *NetworkService) Get(id string) (gocmcapi.Network, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 gocmcapi.Network
+	return r0, notStubbed("NetworkService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *NetworkService) GetWithContext(ctx context.Context, id string) (gocmcapi.Network, error) {
+	m.record("GetWithContext", ctx, id)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, id)
+	}
+	var r0 gocmcapi.Network
+	return r0, notStubbed("NetworkService.GetWithContext")
+}
+
+// Update records the call and runs UpdateFunc
+func (m *NetworkService) Update(id string, name string, description string, opts ...gocmcapi.CallOption) error {
+	args := []interface{}{id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Update", args...)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(id, name, description, opts...)
+	}
+	return notStubbed("NetworkService.Update")
+}
+
+// UpdateWithContext records the call and runs UpdateWithContextFunc
+func (m *NetworkService) UpdateWithContext(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) error {
+	args := []interface{}{ctx, id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateWithContext", args...)
+	if m.UpdateWithContextFunc != nil {
+		return m.UpdateWithContextFunc(ctx, id, name, description, opts...)
+	}
+	return notStubbed("NetworkService.UpdateWithContext")
+}
+
+// UpdateAsync records the call and runs UpdateAsyncFunc
+func (m *NetworkService) UpdateAsync(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateAsync", args...)
+	if m.UpdateAsyncFunc != nil {
+		return m.UpdateAsyncFunc(ctx, id, name, description, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("NetworkService.UpdateAsync")
+}
+
+// Delete records the call and runs DeleteFunc
+func (m *NetworkService) Delete(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Delete", args...)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("NetworkService.Delete")
+}
+
+// DeleteWithContext records the call and runs DeleteWithContextFunc
+func (m *NetworkService) DeleteWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteWithContext", args...)
+	if m.DeleteWithContextFunc != nil {
+		return m.DeleteWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("NetworkService.DeleteWithContext")
+}
+
+// DeleteAsync records the call and runs DeleteAsyncFunc
+func (m *NetworkService) DeleteAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAsync", args...)
+	if m.DeleteAsyncFunc != nil {
+		return m.DeleteAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("NetworkService.DeleteAsync")
+}
+
+// ChangeFirewall records the call and runs ChangeFirewallFunc
+func (m *NetworkService) ChangeFirewall(id string, firewallID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, firewallID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ChangeFirewall", args...)
+	if m.ChangeFirewallFunc != nil {
+		return m.ChangeFirewallFunc(id, firewallID, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("NetworkService.ChangeFirewall")
+}
+
+// ChangeFirewallWithContext records the call and runs ChangeFirewallWithContextFunc
+func (m *NetworkService) ChangeFirewallWithContext(ctx context.Context, id string, firewallID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, firewallID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ChangeFirewallWithContext", args...)
+	if m.ChangeFirewallWithContextFunc != nil {
+		return m.ChangeFirewallWithContextFunc(ctx, id, firewallID, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("NetworkService.ChangeFirewallWithContext")
+}
+
+// ChangeFirewallAsync records the call and runs ChangeFirewallAsyncFunc
+func (m *NetworkService) ChangeFirewallAsync(ctx context.Context, id string, firewallID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, firewallID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ChangeFirewallAsync", args...)
+	if m.ChangeFirewallAsyncFunc != nil {
+		return m.ChangeFirewallAsyncFunc(ctx, id, firewallID, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("NetworkService.ChangeFirewallAsync")
+}
+
+// CreateVPCNetwork records the call and runs CreateVPCNetworkFunc
+func (m *NetworkService) CreateVPCNetwork(vpcID string, name string, description string, gateway string, netmask string, firewallID string) (gocmcapi.ResultResponse, error) {
+	m.record("CreateVPCNetwork", vpcID, name, description, gateway, netmask, firewallID)
+	if m.CreateVPCNetworkFunc != nil {
+		return m.CreateVPCNetworkFunc(vpcID, name, description, gateway, netmask, firewallID)
+	}
+	var r0 gocmcapi.ResultResponse
+	return r0, notStubbed("NetworkService.CreateVPCNetwork")
+}
+
+// CreateVPCNetworkWithContext records the call and runs CreateVPCNetworkWithContextFunc
+func (m *NetworkService) CreateVPCNetworkWithContext(ctx context.Context, vpcID string, name string, description string, gateway string, netmask string, firewallID string) (gocmcapi.ResultResponse, error) {
+	m.record("CreateVPCNetworkWithContext", ctx, vpcID, name, description, gateway, netmask, firewallID)
+	if m.CreateVPCNetworkWithContextFunc != nil {
+		return m.CreateVPCNetworkWithContextFunc(ctx, vpcID, name, description, gateway, netmask, firewallID)
+	}
+	var r0 gocmcapi.ResultResponse
+	return r0, notStubbed("NetworkService.CreateVPCNetworkWithContext")
+}
+
+// RegionService is a programmable fake of gocmcapi.RegionService
+type RegionService struct {
+	Mock
+
+	ListFunc            func() ([]gocmcapi.Region, error)
+	ListWithContextFunc func(ctx context.Context) ([]gocmcapi.Region, error)
+}
+
+var _ gocmcapi.RegionService = (*RegionService)(nil)
+
+// List records the call and runs ListFunc
+func (m *RegionService) List() ([]gocmcapi.Region, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []gocmcapi.Region
+	return r0, notStubbed("RegionService.List")
+}
+
+// ListWithContext records the call and runs ListWithContextFunc
+func (m *RegionService) ListWithContext(ctx context.Context) ([]gocmcapi.Region, error) {
+	m.record("ListWithContext", ctx)
+	if m.ListWithContextFunc != nil {
+		return m.ListWithContextFunc(ctx)
+	}
+	var r0 []gocmcapi.Region
+	return r0, notStubbed("RegionService.ListWithContext")
+}
+
+// ServerService is a programmable fake of gocmcapi.ServerService
+type ServerService struct {
+	Mock
+
+	GetFunc                              func(id string) (gocmcapi.Server, error)
+	GetWithContextFunc                   func(ctx context.Context, id string) (gocmcapi.Server, error)
+	CreateFunc                           func(params map[string]interface{}, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateWithContextFunc                func(ctx context.Context, params map[string]interface{}, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateAsyncFunc                      func(ctx context.Context, params map[string]interface{}, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DeleteFunc                           func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteWithContextFunc                func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteAsyncFunc                      func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	AddSecondaryIPFunc                   func(id string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	AddSecondaryIPWithContextFunc        func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	AddSecondaryIPAsyncFunc              func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	RemoveSecondaryIPFunc                func(id string, ip4Address string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	RemoveSecondaryIPWithContextFunc     func(ctx context.Context, id string, ip4Address string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	RemoveSecondaryIPAsyncFunc           func(ctx context.Context, id string, ip4Address string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	AddNicFunc                           func(id string, networkID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	AddNicWithContextFunc                func(ctx context.Context, id string, networkID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	AddNicAsyncFunc                      func(ctx context.Context, id string, networkID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	RemoveNicFunc                        func(id string, nicID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	RemoveNicWithContextFunc             func(ctx context.Context, id string, nicID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	RemoveNicAsyncFunc                   func(ctx context.Context, id string, nicID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DisableBackupFunc                    func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DisableBackupWithContextFunc         func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DisableBackupAsyncFunc               func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	EnableBackupFunc                     func(id string, intervalType string, scheduleTime string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	EnableBackupWithContextFunc          func(ctx context.Context, id string, intervalType string, scheduleTime string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	EnableBackupAsyncFunc                func(ctx context.Context, id string, intervalType string, scheduleTime string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DisablePrivateNetworkFunc            func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DisablePrivateNetworkWithContextFunc func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DisablePrivateNetworkAsyncFunc       func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	EnablePrivateNetworkFunc             func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	EnablePrivateNetworkWithContextFunc  func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	EnablePrivateNetworkAsyncFunc        func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	ResetPasswordFunc                    func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	ResetPasswordWithContextFunc         func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	ResetPasswordAsyncFunc               func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	RestartFunc                          func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	RestartWithContextFunc               func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	RestartAsyncFunc                     func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	StopFunc                             func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	StopWithContextFunc                  func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	StopAsyncFunc                        func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	StartFunc                            func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	StartWithContextFunc                 func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	StartAsyncFunc                       func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	RestoreSnapshotFunc                  func(id string, snapshotID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	RestoreSnapshotWithContextFunc       func(ctx context.Context, id string, snapshotID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	RestoreSnapshotAsyncFunc             func(ctx context.Context, id string, snapshotID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	TakeSnapshotFunc                     func(id string, name string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	TakeSnapshotWithContextFunc          func(ctx context.Context, id string, name string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	TakeSnapshotAsyncFunc                func(ctx context.Context, id string, name string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	ResizeFunc                           func(id string, cpu int, ramGb int, rootGb int, gpu int, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	ResizeWithContextFunc                func(ctx context.Context, id string, cpu int, ramGb int, rootGb int, gpu int, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	ResizeAsyncFunc                      func(ctx context.Context, id string, cpu int, ramGb int, rootGb int, gpu int, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	GetConsoleURLFunc                    func(id string) (string, error)
+	GetConsoleURLWithContextFunc         func(ctx context.Context, id string) (string, error)
+	RenameFunc                           func(id string, newName string) (string, error)
+	RenameWithContextFunc                func(ctx context.Context, id string, newName string) (string, error)
+	UpdateScheduleTimeFunc               func(id string, intervalType string, scheduleTime string) (string, error)
+	UpdateScheduleTimeWithContextFunc    func(ctx context.Context, id string, intervalType string, scheduleTime string) (string, error)
+}
+
+var _ gocmcapi.ServerService = (*ServerService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *ServerService) Get(id string) (gocmcapi.Server, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 gocmcapi.Server
+	return r0, notStubbed("ServerService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *ServerService) GetWithContext(ctx context.Context, id string) (gocmcapi.Server, error) {
+	m.record("GetWithContext", ctx, id)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, id)
+	}
+	var r0 gocmcapi.Server
+	return r0, notStubbed("ServerService.GetWithContext")
+}
+
+// Create records the call and runs CreateFunc
+func (m *ServerService) Create(params map[string]interface{}, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{params}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Create", args...)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(params, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.Create")
+}
+
+// CreateWithContext records the call and runs CreateWithContextFunc
+func (m *ServerService) CreateWithContext(ctx context.Context, params map[string]interface{}, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, params}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateWithContext", args...)
+	if m.CreateWithContextFunc != nil {
+		return m.CreateWithContextFunc(ctx, params, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.CreateWithContext")
+}
+
+// CreateAsync records the call and runs CreateAsyncFunc
+func (m *ServerService) CreateAsync(ctx context.Context, params map[string]interface{}, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, params}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateAsync", args...)
+	if m.CreateAsyncFunc != nil {
+		return m.CreateAsyncFunc(ctx, params, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.CreateAsync")
+}
+
+// Delete records the call and runs DeleteFunc
+func (m *ServerService) Delete(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Delete", args...)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.Delete")
+}
+
+// DeleteWithContext records the call and runs DeleteWithContextFunc
+func (m *ServerService) DeleteWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteWithContext", args...)
+	if m.DeleteWithContextFunc != nil {
+		return m.DeleteWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.DeleteWithContext")
+}
+
+// DeleteAsync records the call and runs DeleteAsyncFunc
+func (m *ServerService) DeleteAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAsync", args...)
+	if m.DeleteAsyncFunc != nil {
+		return m.DeleteAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.DeleteAsync")
+}
+
+// AddSecondaryIP records the call and runs AddSecondaryIPFunc
+func (m *ServerService) AddSecondaryIP(id string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("AddSecondaryIP", args...)
+	if m.AddSecondaryIPFunc != nil {
+		return m.AddSecondaryIPFunc(id, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.AddSecondaryIP")
+}
+
+// AddSecondaryIPWithContext records the call and runs AddSecondaryIPWithContextFunc
+func (m *ServerService) AddSecondaryIPWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("AddSecondaryIPWithContext", args...)
+	if m.AddSecondaryIPWithContextFunc != nil {
+		return m.AddSecondaryIPWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.AddSecondaryIPWithContext")
+}
+
+// AddSecondaryIPAsync records the call and runs AddSecondaryIPAsyncFunc
+func (m *ServerService) AddSecondaryIPAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("AddSecondaryIPAsync", args...)
+	if m.AddSecondaryIPAsyncFunc != nil {
+		return m.AddSecondaryIPAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.AddSecondaryIPAsync")
+}
+
+// RemoveSecondaryIP records the call and runs RemoveSecondaryIPFunc
+func (m *ServerService) RemoveSecondaryIP(id string, ip4Address string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, ip4Address}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RemoveSecondaryIP", args...)
+	if m.RemoveSecondaryIPFunc != nil {
+		return m.RemoveSecondaryIPFunc(id, ip4Address, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.RemoveSecondaryIP")
+}
+
+// RemoveSecondaryIPWithContext records the call and runs RemoveSecondaryIPWithContextFunc
+func (m *ServerService) RemoveSecondaryIPWithContext(ctx context.Context, id string, ip4Address string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, ip4Address}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RemoveSecondaryIPWithContext", args...)
+	if m.RemoveSecondaryIPWithContextFunc != nil {
+		return m.RemoveSecondaryIPWithContextFunc(ctx, id, ip4Address, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.RemoveSecondaryIPWithContext")
+}
+
+// RemoveSecondaryIPAsync records the call and runs RemoveSecondaryIPAsyncFunc
+func (m *ServerService) RemoveSecondaryIPAsync(ctx context.Context, id string, ip4Address string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, ip4Address}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RemoveSecondaryIPAsync", args...)
+	if m.RemoveSecondaryIPAsyncFunc != nil {
+		return m.RemoveSecondaryIPAsyncFunc(ctx, id, ip4Address, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.RemoveSecondaryIPAsync")
+}
+
+// AddNic records the call and runs AddNicFunc
+func (m *ServerService) AddNic(id string, networkID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, networkID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("AddNic", args...)
+	if m.AddNicFunc != nil {
+		return m.AddNicFunc(id, networkID, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.AddNic")
+}
+
+// AddNicWithContext records the call and runs AddNicWithContextFunc
+func (m *ServerService) AddNicWithContext(ctx context.Context, id string, networkID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, networkID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("AddNicWithContext", args...)
+	if m.AddNicWithContextFunc != nil {
+		return m.AddNicWithContextFunc(ctx, id, networkID, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.AddNicWithContext")
+}
+
+// AddNicAsync records the call and runs AddNicAsyncFunc
+func (m *ServerService) AddNicAsync(ctx context.Context, id string, networkID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, networkID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("AddNicAsync", args...)
+	if m.AddNicAsyncFunc != nil {
+		return m.AddNicAsyncFunc(ctx, id, networkID, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.AddNicAsync")
+}
+
+// RemoveNic records the call and runs RemoveNicFunc
+func (m *ServerService) RemoveNic(id string, nicID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, nicID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RemoveNic", args...)
+	if m.RemoveNicFunc != nil {
+		return m.RemoveNicFunc(id, nicID, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.RemoveNic")
+}
+
+// RemoveNicWithContext records the call and runs RemoveNicWithContextFunc
+func (m *ServerService) RemoveNicWithContext(ctx context.Context, id string, nicID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, nicID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RemoveNicWithContext", args...)
+	if m.RemoveNicWithContextFunc != nil {
+		return m.RemoveNicWithContextFunc(ctx, id, nicID, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.RemoveNicWithContext")
+}
+
+// RemoveNicAsync records the call and runs RemoveNicAsyncFunc
+func (m *ServerService) RemoveNicAsync(ctx context.Context, id string, nicID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, nicID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RemoveNicAsync", args...)
+	if m.RemoveNicAsyncFunc != nil {
+		return m.RemoveNicAsyncFunc(ctx, id, nicID, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.RemoveNicAsync")
+}
+
+// DisableBackup records the call and runs DisableBackupFunc
+func (m *ServerService) DisableBackup(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DisableBackup", args...)
+	if m.DisableBackupFunc != nil {
+		return m.DisableBackupFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.DisableBackup")
+}
+
+// DisableBackupWithContext records the call and runs DisableBackupWithContextFunc
+func (m *ServerService) DisableBackupWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DisableBackupWithContext", args...)
+	if m.DisableBackupWithContextFunc != nil {
+		return m.DisableBackupWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.DisableBackupWithContext")
+}
+
+// DisableBackupAsync records the call and runs DisableBackupAsyncFunc
+func (m *ServerService) DisableBackupAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DisableBackupAsync", args...)
+	if m.DisableBackupAsyncFunc != nil {
+		return m.DisableBackupAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.DisableBackupAsync")
+}
+
+// EnableBackup records the call and runs EnableBackupFunc
+func (m *ServerService) EnableBackup(id string, intervalType string, scheduleTime string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, intervalType, scheduleTime}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("EnableBackup", args...)
+	if m.EnableBackupFunc != nil {
+		return m.EnableBackupFunc(id, intervalType, scheduleTime, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.EnableBackup")
+}
+
+// EnableBackupWithContext records the call and runs EnableBackupWithContextFunc
+func (m *ServerService) EnableBackupWithContext(ctx context.Context, id string, intervalType string, scheduleTime string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, intervalType, scheduleTime}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("EnableBackupWithContext", args...)
+	if m.EnableBackupWithContextFunc != nil {
+		return m.EnableBackupWithContextFunc(ctx, id, intervalType, scheduleTime, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.EnableBackupWithContext")
+}
+
+// EnableBackupAsync records the call and runs EnableBackupAsyncFunc
+func (m *ServerService) EnableBackupAsync(ctx context.Context, id string, intervalType string, scheduleTime string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, intervalType, scheduleTime}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("EnableBackupAsync", args...)
+	if m.EnableBackupAsyncFunc != nil {
+		return m.EnableBackupAsyncFunc(ctx, id, intervalType, scheduleTime, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.EnableBackupAsync")
+}
+
+// DisablePrivateNetwork records the call and runs DisablePrivateNetworkFunc
+func (m *ServerService) DisablePrivateNetwork(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DisablePrivateNetwork", args...)
+	if m.DisablePrivateNetworkFunc != nil {
+		return m.DisablePrivateNetworkFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.DisablePrivateNetwork")
+}
+
+// DisablePrivateNetworkWithContext records the call and runs DisablePrivateNetworkWithContextFunc
+func (m *ServerService) DisablePrivateNetworkWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DisablePrivateNetworkWithContext", args...)
+	if m.DisablePrivateNetworkWithContextFunc != nil {
+		return m.DisablePrivateNetworkWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.DisablePrivateNetworkWithContext")
+}
+
+// DisablePrivateNetworkAsync records the call and runs DisablePrivateNetworkAsyncFunc
+func (m *ServerService) DisablePrivateNetworkAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DisablePrivateNetworkAsync", args...)
+	if m.DisablePrivateNetworkAsyncFunc != nil {
+		return m.DisablePrivateNetworkAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.DisablePrivateNetworkAsync")
+}
+
+// EnablePrivateNetwork records the call and runs EnablePrivateNetworkFunc
+func (m *ServerService) EnablePrivateNetwork(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("EnablePrivateNetwork", args...)
+	if m.EnablePrivateNetworkFunc != nil {
+		return m.EnablePrivateNetworkFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.EnablePrivateNetwork")
+}
+
+// EnablePrivateNetworkWithContext records the call and runs EnablePrivateNetworkWithContextFunc
+func (m *ServerService) EnablePrivateNetworkWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("EnablePrivateNetworkWithContext", args...)
+	if m.EnablePrivateNetworkWithContextFunc != nil {
+		return m.EnablePrivateNetworkWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.EnablePrivateNetworkWithContext")
+}
+
+// EnablePrivateNetworkAsync records the call and runs EnablePrivateNetworkAsyncFunc
+func (m *ServerService) EnablePrivateNetworkAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("EnablePrivateNetworkAsync", args...)
+	if m.EnablePrivateNetworkAsyncFunc != nil {
+		return m.EnablePrivateNetworkAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.EnablePrivateNetworkAsync")
+}
+
+// ResetPassword records the call and runs ResetPasswordFunc
+func (m *ServerService) ResetPassword(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ResetPassword", args...)
+	if m.ResetPasswordFunc != nil {
+		return m.ResetPasswordFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.ResetPassword")
+}
+
+// ResetPasswordWithContext records the call and runs ResetPasswordWithContextFunc
+func (m *ServerService) ResetPasswordWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ResetPasswordWithContext", args...)
+	if m.ResetPasswordWithContextFunc != nil {
+		return m.ResetPasswordWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.ResetPasswordWithContext")
+}
+
+// ResetPasswordAsync records the call and runs ResetPasswordAsyncFunc
+func (m *ServerService) ResetPasswordAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ResetPasswordAsync", args...)
+	if m.ResetPasswordAsyncFunc != nil {
+		return m.ResetPasswordAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.ResetPasswordAsync")
+}
+
+// Restart records the call and runs RestartFunc
+func (m *ServerService) Restart(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Restart", args...)
+	if m.RestartFunc != nil {
+		return m.RestartFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.Restart")
+}
+
+// RestartWithContext records the call and runs RestartWithContextFunc
+func (m *ServerService) RestartWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RestartWithContext", args...)
+	if m.RestartWithContextFunc != nil {
+		return m.RestartWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.RestartWithContext")
+}
+
+// RestartAsync records the call and runs RestartAsyncFunc
+func (m *ServerService) RestartAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RestartAsync", args...)
+	if m.RestartAsyncFunc != nil {
+		return m.RestartAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.RestartAsync")
+}
+
+// Stop records the call and runs StopFunc
+func (m *ServerService) Stop(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Stop", args...)
+	if m.StopFunc != nil {
+		return m.StopFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.Stop")
+}
+
+// StopWithContext records the call and runs StopWithContextFunc
+func (m *ServerService) StopWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("StopWithContext", args...)
+	if m.StopWithContextFunc != nil {
+		return m.StopWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.StopWithContext")
+}
+
+// StopAsync records the call and runs StopAsyncFunc
+func (m *ServerService) StopAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("StopAsync", args...)
+	if m.StopAsyncFunc != nil {
+		return m.StopAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.StopAsync")
+}
+
+// Start records the call and runs StartFunc
+func (m *ServerService) Start(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Start", args...)
+	if m.StartFunc != nil {
+		return m.StartFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.Start")
+}
+
+// StartWithContext records the call and runs StartWithContextFunc
+func (m *ServerService) StartWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("StartWithContext", args...)
+	if m.StartWithContextFunc != nil {
+		return m.StartWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.StartWithContext")
+}
+
+// StartAsync records the call and runs StartAsyncFunc
+func (m *ServerService) StartAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("StartAsync", args...)
+	if m.StartAsyncFunc != nil {
+		return m.StartAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.StartAsync")
+}
+
+// RestoreSnapshot records the call and runs RestoreSnapshotFunc
+func (m *ServerService) RestoreSnapshot(id string, snapshotID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, snapshotID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RestoreSnapshot", args...)
+	if m.RestoreSnapshotFunc != nil {
+		return m.RestoreSnapshotFunc(id, snapshotID, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.RestoreSnapshot")
+}
+
+// RestoreSnapshotWithContext records the call and runs RestoreSnapshotWithContextFunc
+func (m *ServerService) RestoreSnapshotWithContext(ctx context.Context, id string, snapshotID string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, snapshotID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RestoreSnapshotWithContext", args...)
+	if m.RestoreSnapshotWithContextFunc != nil {
+		return m.RestoreSnapshotWithContextFunc(ctx, id, snapshotID, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("ServerService.RestoreSnapshotWithContext")
+}
+
+// RestoreSnapshotAsync records the call and runs RestoreSnapshotAsyncFunc
+func (m *ServerService) RestoreSnapshotAsync(ctx context.Context, id string, snapshotID string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, snapshotID}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("RestoreSnapshotAsync", args...)
+	if m.RestoreSnapshotAsyncFunc != nil {
+		return m.RestoreSnapshotAsyncFunc(ctx, id, snapshotID, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.RestoreSnapshotAsync")
+}
+
+// TakeSnapshot records the call and runs TakeSnapshotFunc
+func (m *ServerService) TakeSnapshot(id string, name string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, name}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("TakeSnapshot", args...)
+	if m.TakeSnapshotFunc != nil {
+		return m.TakeSnapshotFunc(id, name, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.TakeSnapshot")
+}
+
+// TakeSnapshotWithContext records the call and runs TakeSnapshotWithContextFunc
+func (m *ServerService) TakeSnapshotWithContext(ctx context.Context, id string, name string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, name}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("TakeSnapshotWithContext", args...)
+	if m.TakeSnapshotWithContextFunc != nil {
+		return m.TakeSnapshotWithContextFunc(ctx, id, name, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.TakeSnapshotWithContext")
+}
+
+// TakeSnapshotAsync records the call and runs TakeSnapshotAsyncFunc
+func (m *ServerService) TakeSnapshotAsync(ctx context.Context, id string, name string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, name}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("TakeSnapshotAsync", args...)
+	if m.TakeSnapshotAsyncFunc != nil {
+		return m.TakeSnapshotAsyncFunc(ctx, id, name, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.TakeSnapshotAsync")
+}
+
+// Resize records the call and runs ResizeFunc
+func (m *ServerService) Resize(id string, cpu int, ramGb int, rootGb int, gpu int, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, cpu, ramGb, rootGb, gpu}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Resize", args...)
+	if m.ResizeFunc != nil {
+		return m.ResizeFunc(id, cpu, ramGb, rootGb, gpu, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.Resize")
+}
+
+// ResizeWithContext records the call and runs ResizeWithContextFunc
+func (m *ServerService) ResizeWithContext(ctx context.Context, id string, cpu int, ramGb int, rootGb int, gpu int, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, cpu, ramGb, rootGb, gpu}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ResizeWithContext", args...)
+	if m.ResizeWithContextFunc != nil {
+		return m.ResizeWithContextFunc(ctx, id, cpu, ramGb, rootGb, gpu, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("ServerService.ResizeWithContext")
+}
+
+// ResizeAsync records the call and runs ResizeAsyncFunc
+func (m *ServerService) ResizeAsync(ctx context.Context, id string, cpu int, ramGb int, rootGb int, gpu int, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, cpu, ramGb, rootGb, gpu}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ResizeAsync", args...)
+	if m.ResizeAsyncFunc != nil {
+		return m.ResizeAsyncFunc(ctx, id, cpu, ramGb, rootGb, gpu, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("ServerService.ResizeAsync")
+}
+
+// GetConsoleURL records the call and runs GetConsoleURLFunc
+func (m *ServerService) GetConsoleURL(id string) (string, error) {
+	m.record("GetConsoleURL", id)
+	if m.GetConsoleURLFunc != nil {
+		return m.GetConsoleURLFunc(id)
+	}
+	var r0 string
+	return r0, notStubbed("ServerService.GetConsoleURL")
+}
+
+// GetConsoleURLWithContext records the call and runs GetConsoleURLWithContextFunc
+func (m *ServerService) GetConsoleURLWithContext(ctx context.Context, id string) (string, error) {
+	m.record("GetConsoleURLWithContext", ctx, id)
+	if m.GetConsoleURLWithContextFunc != nil {
+		return m.GetConsoleURLWithContextFunc(ctx, id)
+	}
+	var r0 string
+	return r0, notStubbed("ServerService.GetConsoleURLWithContext")
+}
+
+// Rename records the call and runs RenameFunc
+func (m *ServerService) Rename(id string, newName string) (string, error) {
+	m.record("Rename", id, newName)
+	if m.RenameFunc != nil {
+		return m.RenameFunc(id, newName)
+	}
+	var r0 string
+	return r0, notStubbed("ServerService.Rename")
+}
+
+// RenameWithContext records the call and runs RenameWithContextFunc
+func (m *ServerService) RenameWithContext(ctx context.Context, id string, newName string) (string, error) {
+	m.record("RenameWithContext", ctx, id, newName)
+	if m.RenameWithContextFunc != nil {
+		return m.RenameWithContextFunc(ctx, id, newName)
+	}
+	var r0 string
+	return r0, notStubbed("ServerService.RenameWithContext")
+}
+
+// UpdateScheduleTime records the call and runs UpdateScheduleTimeFunc
+func (m *ServerService) UpdateScheduleTime(id string, intervalType string, scheduleTime string) (string, error) {
+	m.record("UpdateScheduleTime", id, intervalType, scheduleTime)
+	if m.UpdateScheduleTimeFunc != nil {
+		return m.UpdateScheduleTimeFunc(id, intervalType, scheduleTime)
+	}
+	var r0 string
+	return r0, notStubbed("ServerService.UpdateScheduleTime")
+}
+
+// UpdateScheduleTimeWithContext records the call and runs UpdateScheduleTimeWithContextFunc
+func (m *ServerService) UpdateScheduleTimeWithContext(ctx context.Context, id string, intervalType string, scheduleTime string) (string, error) {
+	m.record("UpdateScheduleTimeWithContext", ctx, id, intervalType, scheduleTime)
+	if m.UpdateScheduleTimeWithContextFunc != nil {
+		return m.UpdateScheduleTimeWithContextFunc(ctx, id, intervalType, scheduleTime)
+	}
+	var r0 string
+	return r0, notStubbed("ServerService.UpdateScheduleTimeWithContext")
+}
+
+// SnapshotService is a programmable fake of gocmcapi.SnapshotService
+type SnapshotService struct {
+	Mock
+
+	GetFunc               func(id string) (gocmcapi.Snapshot, error)
+	GetWithContextFunc    func(ctx context.Context, id string) (gocmcapi.Snapshot, error)
+	CreateFunc            func(volumeID string, name string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateWithContextFunc func(ctx context.Context, volumeID string, name string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateAsyncFunc       func(ctx context.Context, volumeID string, name string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DeleteFunc            func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteWithContextFunc func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteAsyncFunc       func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	RenameFunc            func(id string, newName string) error
+	RenameWithContextFunc func(ctx context.Context, id string, newName string) error
+}
+
+var _ gocmcapi.SnapshotService = (*SnapshotService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *SnapshotService) Get(id string) (gocmcapi.Snapshot, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 gocmcapi.Snapshot
+	return r0, notStubbed("SnapshotService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *SnapshotService) GetWithContext(ctx context.Context, id string) (gocmcapi.Snapshot, error) {
+	m.record("GetWithContext", ctx, id)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, id)
+	}
+	var r0 gocmcapi.Snapshot
+	return r0, notStubbed("SnapshotService.GetWithContext")
+}
+
+// Create records the call and runs CreateFunc
+func (m *SnapshotService) Create(volumeID string, name string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{volumeID, name}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Create", args...)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(volumeID, name, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("SnapshotService.Create")
+}
+
+// CreateWithContext records the call and runs CreateWithContextFunc
+func (m *SnapshotService) CreateWithContext(ctx context.Context, volumeID string, name string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, volumeID, name}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateWithContext", args...)
+	if m.CreateWithContextFunc != nil {
+		return m.CreateWithContextFunc(ctx, volumeID, name, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("SnapshotService.CreateWithContext")
+}
+
+// CreateAsync records the call and runs CreateAsyncFunc
+func (m *SnapshotService) CreateAsync(ctx context.Context, volumeID string, name string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, volumeID, name}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateAsync", args...)
+	if m.CreateAsyncFunc != nil {
+		return m.CreateAsyncFunc(ctx, volumeID, name, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("SnapshotService.CreateAsync")
+}
+
+// Delete records the call and runs DeleteFunc
+func (m *SnapshotService) Delete(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Delete", args...)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("SnapshotService.Delete")
+}
+
+// DeleteWithContext records the call and runs DeleteWithContextFunc
+func (m *SnapshotService) DeleteWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteWithContext", args...)
+	if m.DeleteWithContextFunc != nil {
+		return m.DeleteWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("SnapshotService.DeleteWithContext")
+}
+
+// DeleteAsync records the call and runs DeleteAsyncFunc
+func (m *SnapshotService) DeleteAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAsync", args...)
+	if m.DeleteAsyncFunc != nil {
+		return m.DeleteAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("SnapshotService.DeleteAsync")
+}
+
+// Rename records the call and runs RenameFunc
+func (m *SnapshotService) Rename(id string, newName string) error {
+	m.record("Rename", id, newName)
+	if m.RenameFunc != nil {
+		return m.RenameFunc(id, newName)
+	}
+	return notStubbed("SnapshotService.Rename")
+}
+
+// RenameWithContext records the call and runs RenameWithContextFunc
+func (m *SnapshotService) RenameWithContext(ctx context.Context, id string, newName string) error {
+	m.record("RenameWithContext", ctx, id, newName)
+	if m.RenameWithContextFunc != nil {
+		return m.RenameWithContextFunc(ctx, id, newName)
+	}
+	return notStubbed("SnapshotService.RenameWithContext")
+}
+
+// TaskService is a programmable fake of gocmcapi.TaskService
+type TaskService struct {
+	Mock
+
+	GetFunc            func(uuid string) (gocmcapi.TaskStatus, error)
+	GetWithContextFunc func(ctx context.Context, uuid string) (gocmcapi.TaskStatus, error)
+}
+
+var _ gocmcapi.TaskService = (*TaskService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *TaskService) Get(uuid string) (gocmcapi.TaskStatus, error) {
+	m.record("Get", uuid)
+	if m.GetFunc != nil {
+		return m.GetFunc(uuid)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("TaskService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *TaskService) GetWithContext(ctx context.Context, uuid string) (gocmcapi.TaskStatus, error) {
+	m.record("GetWithContext", ctx, uuid)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, uuid)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("TaskService.GetWithContext")
+}
+
+// VPCService is a programmable fake of gocmcapi.VPCService
+type VPCService struct {
+	Mock
+
+	GetFunc               func(id string) (gocmcapi.VPC, error)
+	GetWithContextFunc    func(ctx context.Context, id string) (gocmcapi.VPC, error)
+	CreateFunc            func(name string, description string, region string, cidr string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateWithContextFunc func(ctx context.Context, name string, description string, region string, cidr string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateAsyncFunc       func(ctx context.Context, name string, description string, region string, cidr string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DeleteFunc            func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteWithContextFunc func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteAsyncFunc       func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	UpdateFunc            func(id string, name string, description string, opts ...gocmcapi.CallOption) error
+	UpdateWithContextFunc func(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) error
+	UpdateAsyncFunc       func(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+}
+
+var _ gocmcapi.VPCService = (*VPCService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *VPCService) Get(id string) (gocmcapi.VPC, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 gocmcapi.VPC
+	return r0, notStubbed("VPCService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *VPCService) GetWithContext(ctx context.Context, id string) (gocmcapi.VPC, error) {
+	m.record("GetWithContext", ctx, id)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, id)
+	}
+	var r0 gocmcapi.VPC
+	return r0, notStubbed("VPCService.GetWithContext")
+}
+
+// Create records the call and runs CreateFunc
+func (m *VPCService) Create(name string, description string, region string, cidr string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{name, description, region, cidr}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Create", args...)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(name, description, region, cidr, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("VPCService.Create")
+}
+
+// CreateWithContext records the call and runs CreateWithContextFunc
+func (m *VPCService) CreateWithContext(ctx context.Context, name string, description string, region string, cidr string, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, name, description, region, cidr}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateWithContext", args...)
+	if m.CreateWithContextFunc != nil {
+		return m.CreateWithContextFunc(ctx, name, description, region, cidr, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("VPCService.CreateWithContext")
+}
+
+// CreateAsync records the call and runs CreateAsyncFunc
+func (m *VPCService) CreateAsync(ctx context.Context, name string, description string, region string, cidr string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, name, description, region, cidr}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateAsync", args...)
+	if m.CreateAsyncFunc != nil {
+		return m.CreateAsyncFunc(ctx, name, description, region, cidr, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("VPCService.CreateAsync")
+}
+
+// Delete records the call and runs DeleteFunc
+func (m *VPCService) Delete(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Delete", args...)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("VPCService.Delete")
+}
+
+// DeleteWithContext records the call and runs DeleteWithContextFunc
+func (m *VPCService) DeleteWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteWithContext", args...)
+	if m.DeleteWithContextFunc != nil {
+		return m.DeleteWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("VPCService.DeleteWithContext")
+}
+
+// DeleteAsync records the call and runs DeleteAsyncFunc
+func (m *VPCService) DeleteAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAsync", args...)
+	if m.DeleteAsyncFunc != nil {
+		return m.DeleteAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("VPCService.DeleteAsync")
+}
+
+// Update records the call and runs UpdateFunc
+func (m *VPCService) Update(id string, name string, description string, opts ...gocmcapi.CallOption) error {
+	args := []interface{}{id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Update", args...)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(id, name, description, opts...)
+	}
+	return notStubbed("VPCService.Update")
+}
+
+// UpdateWithContext records the call and runs UpdateWithContextFunc
+func (m *VPCService) UpdateWithContext(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) error {
+	args := []interface{}{ctx, id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateWithContext", args...)
+	if m.UpdateWithContextFunc != nil {
+		return m.UpdateWithContextFunc(ctx, id, name, description, opts...)
+	}
+	return notStubbed("VPCService.UpdateWithContext")
+}
+
+// UpdateAsync records the call and runs UpdateAsyncFunc
+func (m *VPCService) UpdateAsync(ctx context.Context, id string, name string, description string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, name, description}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("UpdateAsync", args...)
+	if m.UpdateAsyncFunc != nil {
+		return m.UpdateAsyncFunc(ctx, id, name, description, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("VPCService.UpdateAsync")
+}
+
+// VolumeService is a programmable fake of gocmcapi.VolumeService
+type VolumeService struct {
+	Mock
+
+	GetFunc               func(id string) (gocmcapi.Volume, error)
+	GetWithContextFunc    func(ctx context.Context, id string) (gocmcapi.Volume, error)
+	CreateFunc            func(params map[string]interface{}, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateWithContextFunc func(ctx context.Context, params map[string]interface{}, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	CreateAsyncFunc       func(ctx context.Context, params map[string]interface{}, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	DeleteFunc            func(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteWithContextFunc func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error)
+	DeleteAsyncFunc       func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	ResizeFunc            func(id string, newSize int, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	ResizeWithContextFunc func(ctx context.Context, id string, newSize int, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error)
+	ResizeAsyncFunc       func(ctx context.Context, id string, newSize int, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error)
+	RenameFunc            func(id string, newName string) error
+	RenameWithContextFunc func(ctx context.Context, id string, newName string) error
+	AttachFunc            func(id string, serverID string) (string, error)
+	AttachWithContextFunc func(ctx context.Context, id string, serverID string) (string, error)
+	DetachFunc            func(id string) (string, error)
+	DetachWithContextFunc func(ctx context.Context, id string) (string, error)
+}
+
+var _ gocmcapi.VolumeService = (*VolumeService)(nil)
+
+// Get records the call and runs GetFunc
+func (m *VolumeService) Get(id string) (gocmcapi.Volume, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 gocmcapi.Volume
+	return r0, notStubbed("VolumeService.Get")
+}
+
+// GetWithContext records the call and runs GetWithContextFunc
+func (m *VolumeService) GetWithContext(ctx context.Context, id string) (gocmcapi.Volume, error) {
+	m.record("GetWithContext", ctx, id)
+	if m.GetWithContextFunc != nil {
+		return m.GetWithContextFunc(ctx, id)
+	}
+	var r0 gocmcapi.Volume
+	return r0, notStubbed("VolumeService.GetWithContext")
+}
+
+// Create records the call and runs CreateFunc
+func (m *VolumeService) Create(params map[string]interface{}, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{params}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Create", args...)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(params, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("VolumeService.Create")
+}
+
+// CreateWithContext records the call and runs CreateWithContextFunc
+func (m *VolumeService) CreateWithContext(ctx context.Context, params map[string]interface{}, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, params}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateWithContext", args...)
+	if m.CreateWithContextFunc != nil {
+		return m.CreateWithContextFunc(ctx, params, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("VolumeService.CreateWithContext")
+}
+
+// CreateAsync records the call and runs CreateAsyncFunc
+func (m *VolumeService) CreateAsync(ctx context.Context, params map[string]interface{}, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, params}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("CreateAsync", args...)
+	if m.CreateAsyncFunc != nil {
+		return m.CreateAsyncFunc(ctx, params, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("VolumeService.CreateAsync")
+}
+
+// Delete records the call and runs DeleteFunc
+func (m *VolumeService) Delete(id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Delete", args...)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("VolumeService.Delete")
+}
+
+// DeleteWithContext records the call and runs DeleteWithContextFunc
+func (m *VolumeService) DeleteWithContext(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteWithContext", args...)
+	if m.DeleteWithContextFunc != nil {
+		return m.DeleteWithContextFunc(ctx, id, opts...)
+	}
+	var r0 gocmcapi.TaskStatus
+	return r0, notStubbed("VolumeService.DeleteWithContext")
+}
+
+// DeleteAsync records the call and runs DeleteAsyncFunc
+func (m *VolumeService) DeleteAsync(ctx context.Context, id string, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("DeleteAsync", args...)
+	if m.DeleteAsyncFunc != nil {
+		return m.DeleteAsyncFunc(ctx, id, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("VolumeService.DeleteAsync")
+}
+
+// Resize records the call and runs ResizeFunc
+func (m *VolumeService) Resize(id string, newSize int, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{id, newSize}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("Resize", args...)
+	if m.ResizeFunc != nil {
+		return m.ResizeFunc(id, newSize, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("VolumeService.Resize")
+}
+
+// ResizeWithContext records the call and runs ResizeWithContextFunc
+func (m *VolumeService) ResizeWithContext(ctx context.Context, id string, newSize int, opts ...gocmcapi.CallOption) (gocmcapi.OrderResponse, gocmcapi.TaskStatus, error) {
+	args := []interface{}{ctx, id, newSize}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ResizeWithContext", args...)
+	if m.ResizeWithContextFunc != nil {
+		return m.ResizeWithContextFunc(ctx, id, newSize, opts...)
+	}
+	var r0 gocmcapi.OrderResponse
+	var r1 gocmcapi.TaskStatus
+	return r0, r1, notStubbed("VolumeService.ResizeWithContext")
+}
+
+// ResizeAsync records the call and runs ResizeAsyncFunc
+func (m *VolumeService) ResizeAsync(ctx context.Context, id string, newSize int, opts ...gocmcapi.CallOption) (*gocmcapi.TaskHandle, error) {
+	args := []interface{}{ctx, id, newSize}
+	for _, arg := range opts {
+		args = append(args, arg)
+	}
+	m.record("ResizeAsync", args...)
+	if m.ResizeAsyncFunc != nil {
+		return m.ResizeAsyncFunc(ctx, id, newSize, opts...)
+	}
+	var r0 *gocmcapi.TaskHandle
+	return r0, notStubbed("VolumeService.ResizeAsync")
+}
+
+// Rename records the call and runs RenameFunc
+func (m *VolumeService) Rename(id string, newName string) error {
+	m.record("Rename", id, newName)
+	if m.RenameFunc != nil {
+		return m.RenameFunc(id, newName)
+	}
+	return notStubbed("VolumeService.Rename")
+}
+
+// RenameWithContext records the call and runs RenameWithContextFunc
+func (m *VolumeService) RenameWithContext(ctx context.Context, id string, newName string) error {
+	m.record("RenameWithContext", ctx, id, newName)
+	if m.RenameWithContextFunc != nil {
+		return m.RenameWithContextFunc(ctx, id, newName)
+	}
+	return notStubbed("VolumeService.RenameWithContext")
+}
+
+// Attach records the call and runs AttachFunc
+func (m *VolumeService) Attach(id string, serverID string) (string, error) {
+	m.record("Attach", id, serverID)
+	if m.AttachFunc != nil {
+		return m.AttachFunc(id, serverID)
+	}
+	var r0 string
+	return r0, notStubbed("VolumeService.Attach")
+}
+
+// AttachWithContext records the call and runs AttachWithContextFunc
+func (m *VolumeService) AttachWithContext(ctx context.Context, id string, serverID string) (string, error) {
+	m.record("AttachWithContext", ctx, id, serverID)
+	if m.AttachWithContextFunc != nil {
+		return m.AttachWithContextFunc(ctx, id, serverID)
+	}
+	var r0 string
+	return r0, notStubbed("VolumeService.AttachWithContext")
+}
+
+// Detach records the call and runs DetachFunc
+func (m *VolumeService) Detach(id string) (string, error) {
+	m.record("Detach", id)
+	if m.DetachFunc != nil {
+		return m.DetachFunc(id)
+	}
+	var r0 string
+	return r0, notStubbed("VolumeService.Detach")
+}
+
+// DetachWithContext records the call and runs DetachWithContextFunc
+func (m *VolumeService) DetachWithContext(ctx context.Context, id string) (string, error) {
+	m.record("DetachWithContext", ctx, id)
+	if m.DetachWithContextFunc != nil {
+		return m.DetachWithContextFunc(ctx, id)
+	}
+	var r0 string
+	return r0, notStubbed("VolumeService.DetachWithContext")
+}
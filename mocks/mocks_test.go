@@ -0,0 +1,77 @@
+package mocks_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/mocks"
+)
+
+// stopIfRunning is code under test using the client through its interfaces
+func stopIfRunning(ctx context.Context, client *gocmcapi.Client, id string) error {
+	s, err := client.Server.GetWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	if s.State != "running" {
+		return nil
+	}
+	_, err = client.Server.StopWithContext(ctx, id, gocmcapi.WithTimeSettings(gocmcapi.ShortTimeSettings))
+	return err
+}
+
+// recordingT records the assertion failures
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestServiceMock(t *testing.T) {
+	client, err := gocmcapi.NewClient("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	servers := &mocks.ServerService{
+		GetWithContextFunc: func(ctx context.Context, id string) (gocmcapi.Server, error) {
+			return gocmcapi.Server{ID: id, State: "running"}, nil
+		},
+	}
+	client.Server = servers
+
+	err = stopIfRunning(context.Background(), client, "s1")
+	if !errors.Is(err, mocks.ErrNotStubbed) || !strings.Contains(err.Error(), "ServerService.StopWithContext") {
+		t.Errorf("unstubbed StopWithContext error = %v, want ErrNotStubbed", err)
+	}
+
+	servers.StopWithContextFunc = func(ctx context.Context, id string, opts ...gocmcapi.CallOption) (gocmcapi.TaskStatus, error) {
+		if len(opts) != 1 {
+			t.Errorf("StopWithContext got %d options, want 1", len(opts))
+		}
+		return gocmcapi.TaskStatus{Status: "DONE"}, nil
+	}
+	servers.Reset()
+	if err := stopIfRunning(context.Background(), client, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	servers.AssertCalled(t, "GetWithContext", mocks.Anything, "s1")
+	servers.AssertCalled(t, "StopWithContext", mocks.Anything, "s1", mocks.Anything)
+	servers.AssertNumberOfCalls(t, "StopWithContext", 1)
+	servers.AssertNotCalled(t, "Delete")
+	if calls := servers.Calls(); len(calls) != 2 || calls[0].Method != "GetWithContext" || calls[1].Method != "StopWithContext" {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	rt := &recordingT{}
+	if servers.AssertCalled(rt, "StopWithContext", mocks.Anything, "s2", mocks.Anything) || len(rt.errors) != 1 {
+		t.Errorf("AssertCalled with other args reported %v", rt.errors)
+	}
+}
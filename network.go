@@ -1,16 +1,25 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
 )
 
 // NetworkService interface
 type NetworkService interface {
 	Get(id string) (Network, error)
-	Update(id, name, description string) error
-	Delete(id string) (TaskStatus, error)
-	ChangeFirewall(id, firewallID string) (TaskStatus, error)
+	GetWithContext(ctx context.Context, id string) (Network, error)
+	Update(id, name, description string, opts ...CallOption) error
+	UpdateWithContext(ctx context.Context, id, name, description string, opts ...CallOption) error
+	UpdateAsync(ctx context.Context, id, name, description string, opts ...CallOption) (*TaskHandle, error)
+	Delete(id string, opts ...CallOption) (TaskStatus, error)
+	DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	ChangeFirewall(id, firewallID string, opts ...CallOption) (TaskStatus, error)
+	ChangeFirewallWithContext(ctx context.Context, id, firewallID string, opts ...CallOption) (TaskStatus, error)
+	ChangeFirewallAsync(ctx context.Context, id, firewallID string, opts ...CallOption) (*TaskHandle, error)
 	CreateVPCNetwork(vpcID, name, description, gateway, netmask, firewallID string) (ResultResponse, error)
+	CreateVPCNetworkWithContext(ctx context.Context, vpcID, name, description, gateway, netmask, firewallID string) (ResultResponse, error)
 }
 
 // ResultResponse return from CreateVPCNetwork
@@ -39,7 +48,12 @@ type network struct {
 
 // Get Network detail
 func (v *network) Get(id string) (Network, error) {
-	jsonStr, err := v.client.Get("network/info", map[string]string{"id": id})
+	return v.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (v *network) GetWithContext(ctx context.Context, id string) (Network, error) {
+	jsonStr, err := v.client.GetWithContext(ctx, "network/info", map[string]string{"id": id})
 	var network Network
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &network)
@@ -48,18 +62,53 @@ func (v *network) Get(id string) (Network, error) {
 }
 
 // Delete a Network
-func (v *network) Delete(id string) (TaskStatus, error) {
-	return v.client.LongDeleteTask("network/delete", id, nil, MediumTimeSettings)
+func (v *network) Delete(id string, opts ...CallOption) (TaskStatus, error) {
+	return v.DeleteWithContext(context.Background(), id, opts...)
+}
+
+// DeleteWithContext same as Delete, cancellable through ctx
+func (v *network) DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.DeleteAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
 }
-func (v *network) Update(id, name, description string) error {
-	_, err := v.client.LongTask("network/update", id, map[string]interface{}{"name": name, "description": description}, ShortTimeSettings)
+
+// DeleteAsync same as Delete, returns once the task is submitted
+func (v *network) DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongDeleteTaskAsync(ctx, "network/delete", id, nil, MediumTimeSettings, opts...)
+}
+func (v *network) Update(id, name, description string, opts ...CallOption) error {
+	return v.UpdateWithContext(context.Background(), id, name, description, opts...)
+}
+
+func (v *network) UpdateWithContext(ctx context.Context, id, name, description string, opts ...CallOption) error {
+	handle, err := v.UpdateAsync(ctx, id, name, description, opts...)
+	_, err = waitTask(ctx, handle, err)
 	return err
 }
-func (v *network) ChangeFirewall(id, firewallID string) (TaskStatus, error) {
-	return v.client.LongTask("network/change_firewall", id, map[string]interface{}{"firewall_id": firewallID}, MediumTimeSettings)
+
+// UpdateAsync same as Update, returns once the task is submitted
+func (v *network) UpdateAsync(ctx context.Context, id, name, description string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "network/update", id, map[string]interface{}{"name": name, "description": description}, ShortTimeSettings, opts...)
+}
+func (v *network) ChangeFirewall(id, firewallID string, opts ...CallOption) (TaskStatus, error) {
+	return v.ChangeFirewallWithContext(context.Background(), id, firewallID, opts...)
+}
+
+func (v *network) ChangeFirewallWithContext(ctx context.Context, id, firewallID string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.ChangeFirewallAsync(ctx, id, firewallID, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// ChangeFirewallAsync same as ChangeFirewall, returns once the task is submitted
+func (v *network) ChangeFirewallAsync(ctx context.Context, id, firewallID string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "network/change_firewall", id, map[string]interface{}{"firewall_id": firewallID}, MediumTimeSettings, opts...)
 }
 func (v *network) CreateVPCNetwork(vpcID, name, description, gateway, netmask, firewallID string) (ResultResponse, error) {
-	jsonStr, err := v.client.Post("network/create_vpc_network", map[string]interface{}{
+	return v.CreateVPCNetworkWithContext(context.Background(), vpcID, name, description, gateway, netmask, firewallID)
+}
+
+func (v *network) CreateVPCNetworkWithContext(ctx context.Context, vpcID, name, description, gateway, netmask, firewallID string) (ResultResponse, error) {
+	jsonStr, err := v.client.PostWithContext(ctx, "network/create_vpc_network", map[string]interface{}{
 		"vpc_id":      vpcID,
 		"name":        name,
 		"description": description,
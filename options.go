@@ -0,0 +1,287 @@
+package gocmcapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ClientOption configures a Client created by NewClient
+type ClientOption func(*Client) error
+
+// WithBaseURL overrides the default api url, e.g. for a staging endpoint or a test server
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		baseURL = strings.TrimRight(baseURL, "/")
+		if baseURL == "" {
+			return errors.New("base url must not be empty")
+		}
+		c.apiURL = baseURL
+		return nil
+	}
+}
+
+// WithHTTPClient uses the given http client for every request, its settings
+// (proxy, tls config, transport...) are kept
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("http client must not be nil")
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to send requests
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		if transport == nil {
+			return errors.New("transport must not be nil")
+		}
+		c.transport = transport
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithRequestTimeout limits the time of a single http request, 0 means no limit.
+// It does not limit the time waiting for a task, see TimeSettings for that
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return errors.New("request timeout must not be negative")
+		}
+		c.requestTimeout = timeout
+		return nil
+	}
+}
+
+// WithConnPool tunes the idle connection pool of the client's own transport.
+// It has no effect together with WithHTTPClient or WithTransport, configure
+// the given transport instead
+func WithConnPool(settings ConnPoolSettings) ClientOption {
+	return func(c *Client) error {
+		if settings.MaxIdleConns < 0 || settings.MaxIdleConnsPerHost < 0 || settings.IdleConnTimeout < 0 {
+			return errors.New("connection pool settings must not be negative")
+		}
+		c.connPool = settings
+		return nil
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy, use NoRetryPolicy to disable retries
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MinBackoff < 0 || policy.MaxBackoff < 0 {
+			return errors.New("retry backoff must not be negative")
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return errors.New("retry jitter must be between 0 and 1")
+		}
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimit limits the requests sent by the client. Task polling requests
+// get their own limiter with the same limit, so they can not starve other
+// calls, unless WithTaskPollRateLimit sets another one
+func WithRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) error {
+		if limit.RequestsPerSecond < 0 || limit.Burst < 0 || limit.MaxInFlight < 0 {
+			return errors.New("rate limit must not be negative")
+		}
+		c.rateLimit = limit
+		return nil
+	}
+}
+
+// WithTaskPollRateLimit limits the job/status requests sent while waiting for
+// tasks instead of the limit of WithRateLimit, an empty RateLimit disables it
+func WithTaskPollRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) error {
+		if limit.RequestsPerSecond < 0 || limit.Burst < 0 || limit.MaxInFlight < 0 {
+			return errors.New("rate limit must not be negative")
+		}
+		c.taskPollLimit = &limit
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the client, by default nothing is logged
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) error {
+		if logger == nil {
+			logger = NopLogger{}
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithPollScale multiplies the delay and the interval of task polling by scale,
+// e.g. 0 replays recorded tasks without waiting. Timeouts are not scaled
+func WithPollScale(scale float64) ClientOption {
+	return func(c *Client) error {
+		if scale < 0 {
+			return errors.New("poll scale must not be negative")
+		}
+		c.pollScale = scale
+		return nil
+	}
+}
+
+// AuthMode selects how the api key is sent
+type AuthMode int
+
+const (
+	// AuthModeHeaderAndQuery sends the key in the Authorization header and
+	// as the api_key query param, the query param may end up in proxy logs
+	AuthModeHeaderAndQuery AuthMode = iota
+	// AuthModeHeader only sends the key in the Authorization header
+	AuthModeHeader
+)
+
+// WithAuthMode selects how the api key is sent, AuthModeHeaderAndQuery by
+// default. The query param stays the default so that setups which only
+// check it, e.g. a proxy in front of the api, keep working; AuthModeHeader
+// becomes the default in the next major version. Errors and log lines never
+// contain the key in either mode
+func WithAuthMode(mode AuthMode) ClientOption {
+	return func(c *Client) error {
+		if mode != AuthModeHeaderAndQuery && mode != AuthModeHeader {
+			return fmt.Errorf("unknown auth mode %d", mode)
+		}
+		c.authMode = mode
+		return nil
+	}
+}
+
+// WithRegion pins the client to a region, calls are sent to its endpoint
+// given with WithRegionEndpoints. NewClient fails if the region has no
+// endpoint, use Client.ForRegion to discover it from the api instead
+func WithRegion(region string) ClientOption {
+	return func(c *Client) error {
+		c.region = region
+		return nil
+	}
+}
+
+// WithRegionEndpoints sets the api url of regions, by region id
+func WithRegionEndpoints(endpoints map[string]string) ClientOption {
+	return func(c *Client) error {
+		c.regionEndpoints = endpoints
+		return nil
+	}
+}
+
+// WithTracer traces every http request, long running call and task wait
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) error {
+		if tracer == nil {
+			tracer = nopTracer{}
+		}
+		c.tracer = tracer
+		return nil
+	}
+}
+
+// WithMetrics records request latencies, task waits and unpaid orders
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(c *Client) error {
+		if metrics == nil {
+			metrics = nopMetrics{}
+		}
+		c.metrics = metrics
+		return nil
+	}
+}
+
+// WithDryRun records Post, Put and Delete requests instead of sending them,
+// long tasks and orders return a DONE TaskStatus and a paid OrderResponse
+// without waiting. GET requests and read-only POSTs such as
+// FirewallVPC.ValidateRules still run. The recorded operations are returned
+// by Client.Plan
+func WithDryRun(dryRun bool) ClientOption {
+	return func(c *Client) error {
+		c.dryRun = dryRun
+		return nil
+	}
+}
+
+// WithReconciler looks up the resource of action when a LongTask or Order
+// submission of it got no answer, so a retry does not create a duplicate,
+// e.g. WithReconciler("server/create", ReconcileByParam("name", lookup))
+func WithReconciler(action string, reconcile Reconciler) ClientOption {
+	return func(c *Client) error {
+		if c.reconcilers == nil {
+			c.reconcilers = make(map[string]Reconciler)
+		}
+		c.reconcilers[action] = reconcile
+		return nil
+	}
+}
+
+// WithCache caches the responses of info endpoints such as server/info, a
+// mutating call invalidates the entries of the resource ids it is sent with.
+// Updating or deleting a firewall rule invalidates every firewall_vpc entry,
+// the id of its firewall is not sent
+func WithCache(settings CacheSettings) ClientOption {
+	return func(c *Client) error {
+		c.cache = newResponseCache(settings)
+		return nil
+	}
+}
+
+// WithCircuitBreaker fails requests fast with ErrCircuitOpen once
+// settings.FailureThreshold consecutive requests failed, a probe request is
+// sent after settings.Cooldown and closes the circuit when it succeeds
+func WithCircuitBreaker(settings CircuitBreakerSettings) ClientOption {
+	return func(c *Client) error {
+		c.breaker = newCircuitBreaker(settings)
+		return nil
+	}
+}
+
+// WithTaskJournal records submitted tasks in journal until they are DONE or
+// ERROR, Client.ResumeTasks returns handles for the unfinished ones
+func WithTaskJournal(journal TaskJournal) ClientOption {
+	return func(c *Client) error {
+		c.journal = journal
+		return nil
+	}
+}
+
+// WithTaskObserver reports the progress of every task wait to observer,
+// see also WithTaskObserverContext
+func WithTaskObserver(observer TaskObserver) ClientOption {
+	return func(c *Client) error {
+		c.taskObserver = observer
+		return nil
+	}
+}
+
+// WithEndpointTimeSettings overrides the TimeSettings of long running calls by
+// endpoint, e.g. "server_action/resize", zero fields keep the method settings.
+// WithTimeSettings overrides them for a single call
+func WithEndpointTimeSettings(settings map[string]TimeSettings) ClientOption {
+	return func(c *Client) error {
+		if c.endpointTimes == nil {
+			c.endpointTimes = make(map[string]TimeSettings)
+		}
+		for endpoint, timeSettings := range settings {
+			c.endpointTimes[endpoint] = timeSettings
+		}
+		return nil
+	}
+}
@@ -0,0 +1,9 @@
+module github.com/cmc-cloud/gocmcapi/otel
+
+go 1.15
+
+require (
+	github.com/cmc-cloud/gocmcapi v0.0.0-20261018031009-8026c0ea9f78
+	go.opentelemetry.io/otel v1.0.0
+	go.opentelemetry.io/otel/trace v1.0.0
+)
@@ -0,0 +1,75 @@
+// Package otel traces gocmcapi calls with OpenTelemetry
+//
+//	client, err := gocmcapi.NewClient(apikey, gocmcapi.WithTracer(otel.NewTracer(nil)))
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer used when none is given
+const InstrumentationName = "github.com/cmc-cloud/gocmcapi"
+
+// Tracer adapts an OpenTelemetry tracer to gocmcapi.Tracer
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ gocmcapi.Tracer = (*Tracer)(nil)
+
+// NewTracer wraps tracer, a nil tracer uses the global tracer provider
+func NewTracer(tracer trace.Tracer) *Tracer {
+	if tracer == nil {
+		tracer = otel.Tracer(InstrumentationName)
+	}
+	return &Tracer{tracer: tracer}
+}
+
+// Start starts an OpenTelemetry span
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, gocmcapi.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &Span{span: span}
+}
+
+// Span adapts an OpenTelemetry span to gocmcapi.Span
+type Span struct {
+	span trace.Span
+}
+
+// SetAttribute sets an attribute of the span
+func (s *Span) SetAttribute(key string, value interface{}) {
+	s.span.SetAttributes(attributeOf(key, value))
+}
+
+// End records err on the span and ends it
+func (s *Span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+func attributeOf(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
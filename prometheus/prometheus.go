@@ -0,0 +1,247 @@
+// Package prometheus exports gocmcapi metrics in the Prometheus text format,
+// without depending on the Prometheus client library
+//
+//	metrics := prometheus.NewMetrics("")
+//	http.Handle("/metrics", metrics)
+//	client, err := gocmcapi.NewClient(apikey, gocmcapi.WithMetrics(metrics))
+//
+// Waits approaching their timeout can be alerted on with the
+// cmc_task_wait_timeout_ratio histogram, e.g.
+//
+//	histogram_quantile(0.95, sum by (command, le) (rate(cmc_task_wait_timeout_ratio_bucket{command="server_action/restore_snapshot"}[1h]))) > 0.8
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+)
+
+// DefaultNamespace is the metric name prefix used when none is given
+const DefaultNamespace = "cmc"
+
+// ContentType is the content type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var (
+	// RequestBuckets are the buckets of request durations, in seconds
+	RequestBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+	// TaskWaitBuckets are the buckets of task wait durations, in seconds,
+	// spanning ShortTimeSettings to OneDayTimeSettings
+	TaskWaitBuckets = []float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200, 14400, 43200, 86400}
+	// TimeoutRatioBuckets are the buckets of task wait durations divided by
+	// their timeout
+	TimeoutRatioBuckets = []float64{.1, .25, .5, .75, .8, .9, .95, 1}
+)
+
+// Metrics implements gocmcapi.Metrics and serves the metrics over http
+type Metrics struct {
+	mu       sync.Mutex
+	families []*family
+
+	requests       *family
+	requestSeconds *family
+	taskSeconds    *family
+	timeoutRatio   *family
+	taskTimeout    *family
+	ordersUnpaid   *family
+}
+
+var (
+	_ gocmcapi.Metrics = (*Metrics)(nil)
+	_ http.Handler     = (*Metrics)(nil)
+)
+
+// NewMetrics creates the metrics, an empty namespace uses DefaultNamespace
+func NewMetrics(namespace string) *Metrics {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	m := &Metrics{}
+	m.requests = m.add(namespace+"_api_requests_total", "counter",
+		"Number of api requests by method, endpoint and http status, status is 0 when no response was received.", nil)
+	m.requestSeconds = m.add(namespace+"_api_request_duration_seconds", "histogram",
+		"Duration of api requests by method, endpoint and http status.", RequestBuckets)
+	m.taskSeconds = m.add(namespace+"_task_wait_duration_seconds", "histogram",
+		"Duration of task waits by command and outcome (DONE, ERROR, timeout, canceled, unknown).", TaskWaitBuckets)
+	m.timeoutRatio = m.add(namespace+"_task_wait_timeout_ratio", "histogram",
+		"Duration of task waits divided by their timeout, by command.", TimeoutRatioBuckets)
+	m.taskTimeout = m.add(namespace+"_task_wait_timeout_seconds", "gauge",
+		"Timeout of the last task wait by command.", nil)
+	m.ordersUnpaid = m.add(namespace+"_orders_unpaid_total", "counter",
+		"Number of orders which were not paid, by endpoint.", nil)
+	return m
+}
+
+// ObserveRequest records an api request
+func (m *Metrics) ObserveRequest(method, endpoint string, statusCode int, duration time.Duration) {
+	labels := []string{"method", method, "endpoint", endpoint, "status", strconv.Itoa(statusCode)}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests.series(labels).value++
+	m.requestSeconds.series(labels).observe(duration.Seconds())
+}
+
+// ObserveTaskWait records a task wait
+func (m *Metrics) ObserveTaskWait(command string, outcome gocmcapi.TaskOutcome, duration, timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.taskSeconds.series([]string{"command", command, "outcome", string(outcome)}).observe(duration.Seconds())
+	if timeout > 0 {
+		m.timeoutRatio.series([]string{"command", command}).observe(duration.Seconds() / timeout.Seconds())
+		m.taskTimeout.series([]string{"command", command}).value = timeout.Seconds()
+	}
+}
+
+// IncOrderUnpaid records an unpaid order
+func (m *Metrics) IncOrderUnpaid(endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ordersUnpaid.series([]string{"endpoint", endpoint}).value++
+}
+
+// ServeHTTP serves the metrics in the text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the text exposition format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range m.families {
+		f.write(cw)
+	}
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+func (m *Metrics) add(name, kind, help string, buckets []float64) *family {
+	f := &family{name: name, kind: kind, help: help, buckets: buckets, byKey: make(map[string]*series)}
+	m.families = append(m.families, f)
+	return f
+}
+
+// family is a metric with all its label sets
+type family struct {
+	name    string
+	kind    string
+	help    string
+	buckets []float64
+	byKey   map[string]*series
+}
+
+type series struct {
+	labels  string
+	value   float64 // Counter or gauge value, histogram sum
+	count   uint64
+	buckets []float64
+	counts  []uint64 // Non cumulative bucket counts, counts[i] is in (buckets[i-1], buckets[i]]
+}
+
+// series returns the series of labels, given as name, value pairs
+func (f *family) series(labels []string) *series {
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, labels[i]+`="`+escape(labels[i+1])+`"`)
+	}
+	key := strings.Join(pairs, ",")
+	s, ok := f.byKey[key]
+	if !ok {
+		s = &series{labels: key, buckets: f.buckets, counts: make([]uint64, len(f.buckets))}
+		f.byKey[key] = s
+	}
+	return s
+}
+
+func (s *series) observe(v float64) {
+	s.value += v
+	s.count++
+	for i, bound := range s.buckets {
+		if v <= bound {
+			s.counts[i]++
+			return
+		}
+	}
+}
+
+func (f *family) write(w *countingWriter) {
+	if len(f.byKey) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(f.byKey))
+	for key := range f.byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+	for _, key := range keys {
+		s := f.byKey[key]
+		if f.kind != "histogram" {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, braces(s.labels), formatFloat(s.value))
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, braces(join(s.labels, `le="`+formatFloat(bound)+`"`)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, braces(join(s.labels, `le="+Inf"`)), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, braces(s.labels), formatFloat(s.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, braces(s.labels), s.count)
+	}
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func join(labels, label string) string {
+	if labels == "" {
+		return label
+	}
+	return labels + "," + label
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escape escapes a label value as the text format requires
+func escape(v string) string {
+	return labelEscaper.Replace(strings.ToValidUTF8(v, "�"))
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,72 @@
+package prometheus_test
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+	"github.com/cmc-cloud/gocmcapi/prometheus"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestTextFormat(t *testing.T) {
+	m := prometheus.NewMetrics("test")
+	m.ObserveRequest("GET", "server/info", 200, 30*time.Millisecond)
+	m.ObserveRequest("GET", "server/info", 200, 700*time.Millisecond)
+	m.ObserveRequest("POST", "a\"b\\c\nd", 0, 90*time.Second)
+	m.ObserveTaskWait("server_action/stop", gocmcapi.TaskOutcomeDone, 10*time.Second, time.Minute)
+	m.ObserveTaskWait("server_action/start", gocmcapi.TaskOutcomeUnknown, time.Second, 0)
+	m.IncOrderUnpaid("server/create")
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != prometheus.ContentType {
+		t.Errorf("Content-Type = %s", ct)
+	}
+
+	golden := filepath.Join("testdata", "metrics.txt")
+	if *update {
+		if err := ioutil.WriteFile(golden, rec.Body.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Body.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("metrics differ from %s, update them with go test -update and review the git diff\n%s", golden, got)
+	}
+}
+
+func TestTaskWaitCommandWithoutPoll(t *testing.T) {
+	cloud := fakecloud.New()
+	cloud.InjectFault(fakecloud.Fault{Path: "job/status", StatusCode: http.StatusInternalServerError})
+	ts := httptest.NewServer(cloud)
+	defer ts.Close()
+	m := prometheus.NewMetrics("")
+	c, err := gocmcapi.NewClient("key", gocmcapi.WithBaseURL(ts.URL+fakecloud.BasePath), gocmcapi.WithPollScale(0), gocmcapi.WithMetrics(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+	if _, err := c.Server.StopWithContext(context.Background(), s.ID); err == nil {
+		t.Fatal("Stop succeeded, want the poll error")
+	}
+
+	var out bytes.Buffer
+	m.WriteTo(&out)
+	if want := `cmc_task_wait_duration_seconds_count{command="server_action/stop",outcome="unknown"} 1`; !strings.Contains(out.String(), want) {
+		t.Errorf("metrics have no %s\n%s", want, out.String())
+	}
+}
@@ -0,0 +1,98 @@
+package gocmcapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// taskStatusPath is the endpoint polled while waiting for a task
+const taskStatusPath = "job/status"
+
+// RateLimit limits the requests a Client sends to the api
+type RateLimit struct {
+	RequestsPerSecond float64 // Average request rate, 0 means no limit
+	Burst             int     // Requests that can be sent at once above the rate, at least 1
+	MaxInFlight       int     // Max concurrent requests, 0 means no limit
+}
+
+// limiter is a token bucket plus a semaphore for concurrent requests
+type limiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	inFlight chan struct{}
+}
+
+func newLimiter(l RateLimit) *limiter {
+	if l.RequestsPerSecond <= 0 && l.MaxInFlight <= 0 {
+		return nil
+	}
+	burst := float64(l.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	lim := &limiter{
+		rate:   l.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+	if l.MaxInFlight > 0 {
+		lim.inFlight = make(chan struct{}, l.MaxInFlight)
+	}
+	return lim
+}
+
+// acquire blocks until a request may be sent, the returned func must be
+// called once the request is finished
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if err := l.wait(ctx); err != nil {
+		return nil, err
+	}
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// wait takes a token from the bucket, waiting for it if the bucket is empty
+func (l *limiter) wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		// give back the token we did not use
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
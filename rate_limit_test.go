@@ -0,0 +1,81 @@
+package gocmcapi_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+func TestRateLimitMaxInFlight(t *testing.T) {
+	cloud := fakecloud.New()
+	cloud.InjectFault(fakecloud.Fault{Path: "server/info", Latency: 50 * time.Millisecond})
+	c := newFakeClient(t, cloud, gocmcapi.WithRateLimit(gocmcapi.RateLimit{MaxInFlight: 2}))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Server.Get(s.ID); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("6 requests with 2 in flight took %v, want at least 3 rounds of 50ms", elapsed)
+	}
+}
+
+func TestRateLimitRequestsPerSecond(t *testing.T) {
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud, gocmcapi.WithRateLimit(gocmcapi.RateLimit{RequestsPerSecond: 20, Burst: 1}))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := c.Server.Get(s.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("5 requests at 20/s took %v, want at least 200ms", elapsed)
+	}
+}
+
+// Task polls are limited like other calls, but with their own limiter
+func TestTaskPollRateLimitDefaultsToRateLimit(t *testing.T) {
+	cloud := fakecloud.New(fakecloud.WithJobPolls(5))
+	c := newFakeClient(t, cloud, gocmcapi.WithRateLimit(gocmcapi.RateLimit{RequestsPerSecond: 20, Burst: 1}))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+	start := time.Now()
+	if _, err := c.Server.Stop(s.ID); err != nil {
+		t.Fatal(err)
+	}
+	// the stop takes the token of the client limiter, the 6 polls wait for 5 tokens
+	if elapsed := time.Since(start); elapsed < 240*time.Millisecond {
+		t.Errorf("stop took %v, the polls were not limited", elapsed)
+	}
+}
+
+func TestTaskPollRateLimitIsSeparate(t *testing.T) {
+	cloud := fakecloud.New(fakecloud.WithJobPolls(5))
+	c := newFakeClient(t, cloud,
+		gocmcapi.WithRateLimit(gocmcapi.RateLimit{RequestsPerSecond: 1, Burst: 1}),
+		gocmcapi.WithTaskPollRateLimit(gocmcapi.RateLimit{}))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+	start := time.Now()
+	if _, err := c.Server.Stop(s.ID); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("stop took %v, the polls were limited by WithRateLimit", elapsed)
+	}
+}
@@ -0,0 +1,276 @@
+// Package recorder provides an http.RoundTripper that records the api calls of
+// a gocmcapi.Client to a cassette file and replays them offline, so code using
+// Client.Order or Client.LongTask can be tested without a CMC Cloud account.
+//
+// Record once against the real api:
+//
+//	rec, err := recorder.New("testdata/create_server.json", recorder.ModeRecord)
+//	client, err := gocmcapi.NewClient(apiKey, rec.ClientOptions()...)
+//
+// then replay in tests, task waits are not delayed in replay mode:
+//
+//	rec, err := recorder.New("testdata/create_server.json", recorder.ModeReplay)
+//	client, err := gocmcapi.NewClient("any key", rec.ClientOptions()...)
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cmc-cloud/gocmcapi"
+)
+
+// Mode of a Recorder
+type Mode int
+
+const (
+	// ModeRecord sends requests to the api and records them
+	ModeRecord Mode = iota
+	// ModeReplay answers requests from the cassette, nothing is sent
+	ModeReplay
+)
+
+// redacted replaces secrets in recorded interactions
+const redacted = "REDACTED"
+
+// secretParams are query params whose value is never recorded
+var secretParams = []string{"api_key"}
+
+// Request is a recorded request
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query"`
+	Body   string `json:"body"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// Interaction is a recorded request with its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the content of a cassette file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper recording or replaying api calls
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// Option configures a Recorder
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to send requests in record mode,
+// http.DefaultTransport is used by default
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// New creates a Recorder for the cassette file at path. In replay mode the
+// cassette must exist, in record mode it is overwritten
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading cassette %s: %w", path, err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("Error parsing cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// ClientOptions returns the options that make a gocmcapi.Client use this
+// recorder, in replay mode task polling is not delayed
+func (r *Recorder) ClientOptions() []gocmcapi.ClientOption {
+	opts := []gocmcapi.ClientOption{gocmcapi.WithTransport(r)}
+	if r.mode == ModeReplay {
+		opts = append(opts, gocmcapi.WithPollScale(0))
+	}
+	return opts
+}
+
+// Interactions returns a copy of the recorded or loaded interactions
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	interaction := Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       redact(string(body), secretValues(req)),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replay answers with the first unused interaction matching the request, so
+// repeated polls of the same task get their responses in recorded order
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request != recorded {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction left for %s %s?%s in cassette %s", recorded.Method, recorded.Path, recorded.Query, r.path)
+}
+
+// save writes the cassette, r.mu must be held
+func (r *Recorder) save() error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r.cassette); err != nil {
+		return err
+	}
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(r.path, buf.Bytes(), 0644)
+}
+
+// newRequest converts req to its recorded form with secrets redacted. The
+// body of req is read and replaced, so it can still be sent
+func newRequest(req *http.Request) (Request, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return Request{}, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	query := req.URL.Query()
+	for _, param := range secretParams {
+		if query.Get(param) != "" {
+			query.Set(param, redacted)
+		}
+	}
+	secrets := secretValues(req)
+	return Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  redact(query.Encode(), secrets),
+		Body:   redact(string(body), secrets),
+	}, nil
+}
+
+// secretValues returns the api key and other secrets sent with req
+func secretValues(req *http.Request) []string {
+	var secrets []string
+	for _, param := range secretParams {
+		if value := req.URL.Query().Get(param); value != "" {
+			secrets = append(secrets, value, url.QueryEscape(value))
+		}
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		if i := strings.IndexByte(auth, ' '); i >= 0 {
+			auth = auth[i+1:]
+		}
+		secrets = append(secrets, auth)
+	}
+	return secrets
+}
+
+func redact(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+package recorder_test
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+	"github.com/cmc-cloud/gocmcapi/recorder"
+)
+
+const apiKey = "s3cr3t-api-key"
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stop_server.json")
+	cloud := fakecloud.New(fakecloud.WithAPIKey(apiKey))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+	ts := httptest.NewServer(cloud)
+	baseURL := ts.URL + fakecloud.BasePath
+
+	rec, err := recorder.New(path, recorder.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := gocmcapi.NewClient(apiKey, append(rec.ClientOptions(), gocmcapi.WithBaseURL(baseURL), gocmcapi.WithPollScale(0))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Server.Stop(s.ID); err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := c.Server.Get(s.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), apiKey) {
+		t.Errorf("the cassette contains the api key:\n%s", data)
+	}
+
+	// nothing listens at baseURL anymore, replay must not send anything
+	rec, err = recorder.New(path, recorder.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err = gocmcapi.NewClient("another key", append(rec.ClientOptions(), gocmcapi.WithBaseURL(baseURL), gocmcapi.WithRetryPolicy(gocmcapi.NoRetryPolicy))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	status, err := c.Server.Stop(s.ID)
+	if err != nil || status.Status != "DONE" {
+		t.Fatalf("replayed Stop = %+v, %v", status, err)
+	}
+	// the method waits seconds before its first poll without WithPollScale(0)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("replayed Stop took %v, the poll waits were not skipped", elapsed)
+	}
+	replayed, err := c.Server.Get(s.ID)
+	if err != nil || replayed.State != recorded.State || replayed.State != "stopped" {
+		t.Errorf("replayed Get = %+v, %v, recorded %+v", replayed, err, recorded)
+	}
+	if _, err := c.Server.Get(s.ID); err == nil || !strings.Contains(err.Error(), "no recorded interaction left") {
+		t.Errorf("Get past the cassette = %v, want no recorded interaction left", err)
+	}
+}
@@ -0,0 +1,159 @@
+package gocmcapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces secrets in errors and log messages
+const redacted = "REDACTED"
+
+// secretParamParts marks a param as secret when its name contains one of them
+var secretParamParts = []string{"api_key", "pass", "secret", "token", "private_key"}
+
+func isSecretParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range secretParamParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactParams returns a copy of params with secret values replaced
+func redactParams(params map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if isSecretParam(k) {
+			v = redacted
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// redactStringParams is redactParams for query params
+func redactStringParams(params map[string]string) map[string]string {
+	res := make(map[string]string, len(params))
+	for k, v := range params {
+		if isSecretParam(k) {
+			v = redacted
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// redactURL replaces the value of secret query params in rawURL
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	changed := false
+	for k := range query {
+		if isSecretParam(k) {
+			query.Set(k, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return rawURL
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// redactError removes the api key from the url of transport errors
+func redactError(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return &url.Error{Op: urlErr.Op, URL: redactURL(urlErr.URL), Err: urlErr.Err}
+	}
+	return err
+}
+
+// maskKey keeps the last 4 characters of a key
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return strings.Repeat("*", len(key))
+	}
+	return strings.Repeat("*", len(key)-4) + key[len(key)-4:]
+}
+
+// String describes the client with its api key masked
+func (c *Client) String() string {
+	return fmt.Sprintf("gocmcapi.Client{apiURL: %q, apiKey: %q}", c.apiURL, maskKey(c.apiKey))
+}
+
+// GoString is String, so %#v does not print the api key either
+func (c *Client) GoString() string {
+	return c.String()
+}
+
+// redactingLogger replaces the api key in every message and value
+type redactingLogger struct {
+	logger Logger
+	secret string
+}
+
+func (l redactingLogger) Debug(msg string, keyvals ...interface{}) {
+	l.log(LogLevelDebug, msg, keyvals)
+}
+
+func (l redactingLogger) Info(msg string, keyvals ...interface{}) {
+	l.log(LogLevelInfo, msg, keyvals)
+}
+
+func (l redactingLogger) Warn(msg string, keyvals ...interface{}) {
+	l.log(LogLevelWarn, msg, keyvals)
+}
+
+func (l redactingLogger) Error(msg string, keyvals ...interface{}) {
+	l.log(LogLevelError, msg, keyvals)
+}
+
+func (l redactingLogger) log(level LogLevel, msg string, keyvals []interface{}) {
+	msg, keyvals = l.redact(msg), l.redactValues(keyvals)
+	if std, ok := l.logger.(*StdLogger); ok {
+		// skip this frame, so log.Lshortfile reports the caller
+		std.output(1, level, msg, keyvals)
+		return
+	}
+	switch level {
+	case LogLevelDebug:
+		l.logger.Debug(msg, keyvals...)
+	case LogLevelInfo:
+		l.logger.Info(msg, keyvals...)
+	case LogLevelWarn:
+		l.logger.Warn(msg, keyvals...)
+	default:
+		l.logger.Error(msg, keyvals...)
+	}
+}
+
+func (l redactingLogger) redact(s string) string {
+	if l.secret == "" {
+		return s
+	}
+	return strings.Replace(s, l.secret, redacted, -1)
+}
+
+func (l redactingLogger) redactValues(keyvals []interface{}) []interface{} {
+	if l.secret == "" {
+		return keyvals
+	}
+	res := make([]interface{}, len(keyvals))
+	for i, v := range keyvals {
+		if i%2 == 1 && isSecretParam(fmt.Sprint(keyvals[i-1])) {
+			res[i] = redacted
+		} else if s := fmt.Sprint(v); strings.Contains(s, l.secret) {
+			res[i] = l.redact(s)
+		} else {
+			res[i] = v
+		}
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+package gocmcapi_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+const secretKey = "s3cr3t-api-key-1234"
+
+func TestAPIKeyNotInLogsErrorsOrHooks(t *testing.T) {
+	// a closed server makes transport errors, whose url holds the query key
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+	var logs bytes.Buffer
+	c, err := gocmcapi.NewClient(secretKey,
+		gocmcapi.WithBaseURL(ts.URL),
+		gocmcapi.WithRetryPolicy(gocmcapi.NoRetryPolicy),
+		gocmcapi.WithLogger(gocmcapi.NewStdLogger(log.New(&logs, "", 0), gocmcapi.LogLevelDebug)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hooks []string
+	c.OnBeforeRequest(func(ctx context.Context, req *gocmcapi.RequestInfo) error {
+		hooks = append(hooks, fmt.Sprintf("%+v %v", req, req.Header))
+		return nil
+	})
+	c.OnAfterResponse(func(ctx context.Context, resp *gocmcapi.ResponseInfo) {
+		hooks = append(hooks, fmt.Sprintf("%+v %v", resp, resp.Err))
+	})
+
+	var errs []string
+	if _, err := c.Get("server/info", map[string]string{"id": "s1"}); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if _, err := c.LongTask("server_action/stop", "s1", nil, gocmcapi.ShortTimeSettings); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) != 2 || len(hooks) != 4 {
+		t.Fatalf("errors %q, hook calls %q", errs, hooks)
+	}
+	if !strings.Contains(errs[0], "api_key=REDACTED") {
+		t.Errorf("error is not redacted: %q", errs[0])
+	}
+	for _, s := range append(append(errs, hooks...), logs.String()) {
+		if strings.Contains(s, secretKey) {
+			t.Errorf("api key in %q", s)
+		}
+	}
+}
+
+func TestSecretParamsNotInErrors(t *testing.T) {
+	cloud := fakecloud.New()
+	cloud.InjectFault(fakecloud.Fault{Path: "server_action/reset_pass", TaskError: "failed"})
+	c := newFakeClient(t, cloud)
+	s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+	_, err := c.LongTask("server_action/reset_pass", s.ID, map[string]interface{}{"password": "hunter2", "api_key": secretKey}, gocmcapi.ShortTimeSettings)
+	if err == nil {
+		t.Fatal("LongTask succeeded, want the injected error")
+	}
+	if s := err.Error(); strings.Contains(s, "hunter2") || strings.Contains(s, secretKey) || !strings.Contains(s, "password:REDACTED") {
+		t.Errorf("error %q, want the secret params redacted", s)
+	}
+}
+
+func TestClientStringMasksAPIKey(t *testing.T) {
+	c, err := gocmcapi.NewClient(secretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		s := fmt.Sprintf(format, c)
+		if strings.Contains(s, secretKey) || !strings.Contains(s, "1234") {
+			t.Errorf("%s: %s, want the key masked but its last 4 characters", format, s)
+		}
+	}
+}
+
+func TestAuthModeHeader(t *testing.T) {
+	for _, mode := range []gocmcapi.AuthMode{gocmcapi.AuthModeHeaderAndQuery, gocmcapi.AuthModeHeader} {
+		var query, auth string
+		cloud := fakecloud.New(fakecloud.WithAPIKey(secretKey))
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query, auth = r.URL.RawQuery, r.Header.Get("Authorization")
+			cloud.ServeHTTP(w, r)
+		}))
+		c, err := gocmcapi.NewClient(secretKey, gocmcapi.WithBaseURL(ts.URL+fakecloud.BasePath), gocmcapi.WithAuthMode(mode))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+		if _, err := c.Server.Get(s.ID); err != nil {
+			t.Errorf("mode %d: %v", mode, err)
+		}
+		ts.Close()
+
+		if auth != "Bearer "+secretKey {
+			t.Errorf("mode %d: Authorization %q", mode, auth)
+		}
+		if inQuery := strings.Contains(query, secretKey); inQuery != (mode == gocmcapi.AuthModeHeaderAndQuery) {
+			t.Errorf("mode %d: query %q", mode, query)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package gocmcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RegionService interface
+type RegionService interface {
+	List() ([]Region, error)
+	ListWithContext(ctx context.Context) ([]Region, error)
+}
+
+// Region object, APIURL is set when the platform has a region specific endpoint
+type Region struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	APIURL string `json:"api_url"`
+}
+
+type region struct {
+	client *Client
+}
+
+// List available regions
+func (r *region) List() ([]Region, error) {
+	return r.ListWithContext(context.Background())
+}
+
+// ListWithContext same as List, cancellable through ctx
+func (r *region) ListWithContext(ctx context.Context) ([]Region, error) {
+	jsonStr, err := r.client.GetWithContext(ctx, "region/list", nil)
+	var regions []Region
+	if err == nil {
+		err = json.Unmarshal([]byte(jsonStr), &regions)
+	}
+	return regions, err
+}
+
+// CurrentRegion returns the region the client is pinned to, empty if none
+func (c *Client) CurrentRegion() string {
+	return c.region
+}
+
+// ForRegion returns a client pinned to a region, found by id or name in the
+// available regions. Calls are sent to the region endpoint, the APIURL of the
+// region or else the one set with WithRegionEndpoints, a region without
+// endpoint is an error
+func (c *Client) ForRegion(ctx context.Context, name string) (*Client, error) {
+	regions, err := c.Region.ListWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range regions {
+		if strings.EqualFold(r.ID, name) || strings.EqualFold(r.Name, name) {
+			endpoint := r.APIURL
+			if endpoint == "" {
+				endpoint = c.regionEndpoints[r.ID]
+			}
+			if endpoint == "" {
+				return nil, errNoRegionEndpoint(r.ID)
+			}
+			pinned := *c
+			pinned.region = r.ID
+			pinned.apiURL = strings.TrimRight(endpoint, "/")
+			pinned.initServices()
+			return &pinned, nil
+		}
+	}
+	return nil, fmt.Errorf("region %s not found: %w", name, ErrNotFound)
+}
+
+// ErrNoRegionEndpoint is returned for a region without api endpoint
+var ErrNoRegionEndpoint = errors.New("Region has no api endpoint")
+
+func errNoRegionEndpoint(region string) error {
+	return fmt.Errorf("%w: %s, set it with WithRegionEndpoints", ErrNoRegionEndpoint, region)
+}
+
+// UnmarshalJSON fills Location from the zone fields
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	s.Location = Region{ID: s.RegionID, Name: s.RegionName}
+	return nil
+}
+
+// UnmarshalJSON fills Location from the region fields
+func (v *Volume) UnmarshalJSON(data []byte) error {
+	type plain Volume
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+	v.Location = Region{ID: v.RegionID, Name: v.Region}
+	return nil
+}
+
+// UnmarshalJSON fills Location from the zone fields
+func (v *VPC) UnmarshalJSON(data []byte) error {
+	type plain VPC
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+	v.Location = Region{ID: v.RegionID, Name: v.RegionName}
+	return nil
+}
+
+// UnmarshalJSON fills Location from the zone fields
+func (f *FloatingIP) UnmarshalJSON(data []byte) error {
+	type plain FloatingIP
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	f.Location = Region{ID: f.RegionID, Name: f.RegionName}
+	return nil
+}
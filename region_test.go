@@ -0,0 +1,93 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+func TestWithRegionNeedsEndpoint(t *testing.T) {
+	if _, err := gocmcapi.NewClient("key", gocmcapi.WithRegion("sg")); !errors.Is(err, gocmcapi.ErrNoRegionEndpoint) {
+		t.Errorf("NewClient with a region without endpoint: %v, want ErrNoRegionEndpoint", err)
+	}
+	c, err := gocmcapi.NewClient("key", gocmcapi.WithRegion("sg"), gocmcapi.WithRegionEndpoints(map[string]string{"sg": "https://sg.example.com/ver2"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.CurrentRegion() != "sg" {
+		t.Errorf("CurrentRegion() = %s, want sg", c.CurrentRegion())
+	}
+}
+
+func TestForRegionNeedsEndpoint(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"hn","name":"Ha Noi","api_url":""},{"id":"sg","name":"Singapore","api_url":""}]`))
+	}))
+	defer ts.Close()
+	c, err := gocmcapi.NewClient("key", gocmcapi.WithBaseURL(ts.URL),
+		gocmcapi.WithRegionEndpoints(map[string]string{"sg": "https://sg.example.com/ver2/"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ForRegion(context.Background(), "Ha Noi"); !errors.Is(err, gocmcapi.ErrNoRegionEndpoint) {
+		t.Errorf("ForRegion of a region without endpoint: %v, want ErrNoRegionEndpoint", err)
+	}
+	sg, err := c.ForRegion(context.Background(), "Singapore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sg.CurrentRegion() != "sg" || !strings.Contains(sg.String(), `"https://sg.example.com/ver2"`) {
+		t.Errorf("ForRegion(Singapore) = %s in region %s, want the WithRegionEndpoints endpoint", sg, sg.CurrentRegion())
+	}
+}
+
+func TestLocation(t *testing.T) {
+	c := newFakeClient(t, fakecloud.New())
+	hn, err := c.ForRegion(context.Background(), "Ha Noi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := gocmcapi.Region{ID: "hn", Name: "Ha Noi"}
+
+	_, status, err := hn.Server.Create(map[string]interface{}{"name": "web", "region": "hn"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := hn.Server.Get(status.ResultID)
+	if err != nil || server.Location != want {
+		t.Errorf("server location = %+v, %v", server.Location, err)
+	}
+
+	_, status, err = hn.Volume.Create(map[string]interface{}{"name": "data", "size": 10, "region": "Ha Noi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume, err := hn.Volume.Get(status.ResultID)
+	if err != nil || volume.Location != want {
+		t.Errorf("volume location = %+v, %v", volume.Location, err)
+	}
+
+	_, status, err = hn.VPC.Create("net", "", "hn", "10.0.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vpc, err := hn.VPC.Get(status.ResultID)
+	if err != nil || vpc.Location != want {
+		t.Errorf("vpc location = %+v, %v", vpc.Location, err)
+	}
+
+	_, status, err = hn.FloatingIP.Create(vpc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip, err := hn.FloatingIP.Get(status.ResultID)
+	if err != nil || ip.Location != want {
+		t.Errorf("floating ip location = %+v, %v", ip.Location, err)
+	}
+}
@@ -0,0 +1,107 @@
+package gocmcapi
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryPolicy controls how failed api requests are retried
+type RetryPolicy struct {
+	MaxAttempts      int                  // Number of attempts including the first one, 1 or less disables retries
+	MinBackoff       time.Duration        // Wait before the first retry, doubled on each next retry
+	MaxBackoff       time.Duration        // Largest wait between two retries, also caps a Retry-After header
+	Jitter           float64              // Randomize each wait by this fraction (0..1) of it
+	RetryStatusCodes []int                // Http status codes that are retried
+	RetryOnError     func(err error) bool // Decide if a transport error is retried, nil retries every error
+	RetryMutating    bool                 // Also retry POST, PUT and DELETE, they may not be idempotent
+}
+
+// DefaultRetryPolicy retries idempotent GET requests, e.g. job/status and */info,
+// on connection errors, timeouts and gateway errors
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:      4,
+	MinBackoff:       500 * time.Millisecond,
+	MaxBackoff:       10 * time.Second,
+	Jitter:           0.2,
+	RetryStatusCodes: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+}
+
+// NoRetryPolicy disables retries
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, resp *resty.Response, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if method != http.MethodGet && !p.RetryMutating {
+		return false
+	}
+	if err != nil {
+		return p.RetryOnError == nil || p.RetryOnError(err)
+	}
+	if resp == nil {
+		return false
+	}
+	for _, code := range p.RetryStatusCodes {
+		if resp.StatusCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// wait returns the wait before the retry following the given attempt, the
+// Retry-After header of resp is honoured up to MaxBackoff
+func (p RetryPolicy) wait(attempt int, resp *resty.Response) time.Duration {
+	wait := p.backoff(attempt)
+	if after := retryAfter(resp); after > wait {
+		wait = after
+		if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+			wait = p.MaxBackoff
+		}
+	}
+	return wait
+}
+
+// retryAfter returns the wait asked by the Retry-After header of resp, in
+// seconds or as a date, 0 without one
+func retryAfter(resp *resty.Response) time.Duration {
+	if resp == nil || resp.RawResponse == nil {
+		return 0
+	}
+	value := resp.Header().Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// backoff returns the wait before the retry following the given attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if p.Jitter > 0 && wait > 0 {
+		delta := p.Jitter * float64(wait)
+		wait = time.Duration(float64(wait) - delta + rand.Float64()*2*delta)
+	}
+	return wait
+}
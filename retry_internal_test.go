@@ -0,0 +1,33 @@
+package gocmcapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffBounds(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w*time.Millisecond {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w*time.Millisecond)
+		}
+	}
+
+	policy.Jitter = 0.2
+	for attempt := 1; attempt <= 6; attempt++ {
+		base := want[attempt-1] * time.Millisecond
+		for i := 0; i < 100; i++ {
+			got := policy.backoff(attempt)
+			if got < base*8/10 || got > base*12/10 {
+				t.Fatalf("backoff(%d) = %v, want %v +-20%%", attempt, got, base)
+			}
+		}
+	}
+
+	// keeps doubling without MaxBackoff
+	policy = RetryPolicy{MinBackoff: time.Second}
+	if got := policy.backoff(4); got != 8*time.Second {
+		t.Errorf("backoff(4) = %v, want 8s", got)
+	}
+}
@@ -0,0 +1,130 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+// fastRetry is DefaultRetryPolicy without the waits
+var fastRetry = gocmcapi.RetryPolicy{
+	MaxAttempts:      4,
+	MinBackoff:       time.Millisecond,
+	MaxBackoff:       10 * time.Second,
+	RetryStatusCodes: gocmcapi.DefaultRetryPolicy.RetryStatusCodes,
+}
+
+// attempts returns a client of cloud and a func returning the status codes
+// of the attempts it sent
+func attempts(t *testing.T, cloud *fakecloud.Cloud, opts ...gocmcapi.ClientOption) (*gocmcapi.Client, func() []int) {
+	var mu sync.Mutex
+	var codes []int
+	c := newFakeClient(t, cloud, opts...)
+	c.OnAfterResponse(func(ctx context.Context, resp *gocmcapi.ResponseInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		codes = append(codes, resp.StatusCode)
+	})
+	return c, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), codes...)
+	}
+}
+
+func TestRetryGet(t *testing.T) {
+	for _, code := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		cloud := fakecloud.New()
+		cloud.InjectFault(fakecloud.Fault{Path: "server/info", StatusCode: code, Times: 2})
+		c, codes := attempts(t, cloud, gocmcapi.WithRetryPolicy(fastRetry))
+		s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+
+		if _, err := c.Server.Get(s.ID); err != nil {
+			t.Errorf("%d: %v", code, err)
+		}
+		if got := codes(); len(got) != 3 || got[0] != code || got[2] != http.StatusOK {
+			t.Errorf("%d: attempts %v, want two failures then 200", code, got)
+		}
+	}
+}
+
+func TestRetryGetGivesUp(t *testing.T) {
+	cloud := fakecloud.New()
+	cloud.InjectFault(fakecloud.Fault{Path: "server/info", StatusCode: http.StatusBadGateway})
+	c, codes := attempts(t, cloud, gocmcapi.WithRetryPolicy(fastRetry))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+
+	if _, err := c.Server.Get(s.ID); err == nil {
+		t.Error("Get succeeded, want the 502")
+	}
+	if got := codes(); len(got) != fastRetry.MaxAttempts {
+		t.Errorf("attempts %v, want %d", got, fastRetry.MaxAttempts)
+	}
+}
+
+func TestNoRetryOfClientErrors(t *testing.T) {
+	cloud := fakecloud.New()
+	c, codes := attempts(t, cloud, gocmcapi.WithRetryPolicy(fastRetry))
+	if _, err := c.Server.Get("missing"); err == nil {
+		t.Error("Get succeeded, want not found")
+	}
+	if got := codes(); len(got) != 1 {
+		t.Errorf("attempts %v, want 1", got)
+	}
+}
+
+func TestRetryPost(t *testing.T) {
+	for _, mutating := range []bool{false, true} {
+		cloud := fakecloud.New()
+		cloud.InjectFault(fakecloud.Fault{Path: "server_action/stop", StatusCode: http.StatusBadGateway, Times: 1})
+		policy := fastRetry
+		policy.RetryMutating = mutating
+		c, codes := attempts(t, cloud, gocmcapi.WithRetryPolicy(policy))
+		s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+		_, err := c.Server.Stop(s.ID)
+		if mutating && err != nil {
+			t.Errorf("RetryMutating: %v", err)
+		}
+		if !mutating && err == nil {
+			t.Error("the POST was retried without RetryMutating")
+		}
+		if n := count(cloud, "server_action/stop"); n != map[bool]int{false: 0, true: 1}[mutating] {
+			t.Errorf("RetryMutating %v: %d stops handled, attempts %v", mutating, n, codes())
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBackoff time.Duration
+		min, max   time.Duration
+	}{
+		{"honoured", 10 * time.Second, time.Second, 3 * time.Second},
+		{"capped by MaxBackoff", 100 * time.Millisecond, 100 * time.Millisecond, 900 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cloud := fakecloud.New()
+			cloud.InjectFault(fakecloud.Fault{Path: "server/info", StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second, Times: 1})
+			policy := fastRetry
+			policy.MaxBackoff = tt.maxBackoff
+			c := newFakeClient(t, cloud, gocmcapi.WithRetryPolicy(policy))
+			s := cloud.AddServer(gocmcapi.Server{Name: "web"})
+
+			start := time.Now()
+			if _, err := c.Server.Get(s.ID); err != nil {
+				t.Fatal(err)
+			}
+			if elapsed := time.Since(start); elapsed < tt.min || elapsed > tt.max {
+				t.Errorf("retry after %v, want between %v and %v", elapsed, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -1,32 +1,71 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
 )
 
 // ServerService interface
 type ServerService interface {
 	Get(id string) (Server, error)
-	Create(params map[string]interface{}) (OrderResponse, TaskStatus, error)
-	Delete(id string) (TaskStatus, error)
-	AddSecondaryIP(id string) (OrderResponse, TaskStatus, error)
-	RemoveSecondaryIP(id, ip4Address string) (TaskStatus, error)
-	AddNic(id, networkID string) (TaskStatus, error)
-	RemoveNic(id, nicID string) (TaskStatus, error)
-	DisableBackup(id string) (TaskStatus, error)
-	EnableBackup(id, intervalType, scheduleTime string) (OrderResponse, TaskStatus, error)
-	DisablePrivateNetwork(id string) (TaskStatus, error)
-	EnablePrivateNetwork(id string) (TaskStatus, error)
-	ResetPassword(id string) (TaskStatus, error)
-	Restart(id string) (TaskStatus, error)
-	Stop(id string) (TaskStatus, error)
-	Start(id string) (TaskStatus, error)
-	RestoreSnapshot(id string, snapshotID string) (TaskStatus, error)
-	TakeSnapshot(id string, name string) (OrderResponse, TaskStatus, error)
-	Resize(id string, cpu, ramGb, rootGb, gpu int) (OrderResponse, TaskStatus, error)
+	GetWithContext(ctx context.Context, id string) (Server, error)
+	Create(params map[string]interface{}, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateWithContext(ctx context.Context, params map[string]interface{}, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateAsync(ctx context.Context, params map[string]interface{}, opts ...CallOption) (*TaskHandle, error)
+	Delete(id string, opts ...CallOption) (TaskStatus, error)
+	DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	AddSecondaryIP(id string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	AddSecondaryIPWithContext(ctx context.Context, id string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	AddSecondaryIPAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	RemoveSecondaryIP(id, ip4Address string, opts ...CallOption) (TaskStatus, error)
+	RemoveSecondaryIPWithContext(ctx context.Context, id, ip4Address string, opts ...CallOption) (TaskStatus, error)
+	RemoveSecondaryIPAsync(ctx context.Context, id, ip4Address string, opts ...CallOption) (*TaskHandle, error)
+	AddNic(id, networkID string, opts ...CallOption) (TaskStatus, error)
+	AddNicWithContext(ctx context.Context, id, networkID string, opts ...CallOption) (TaskStatus, error)
+	AddNicAsync(ctx context.Context, id, networkID string, opts ...CallOption) (*TaskHandle, error)
+	RemoveNic(id, nicID string, opts ...CallOption) (TaskStatus, error)
+	RemoveNicWithContext(ctx context.Context, id, nicID string, opts ...CallOption) (TaskStatus, error)
+	RemoveNicAsync(ctx context.Context, id, nicID string, opts ...CallOption) (*TaskHandle, error)
+	DisableBackup(id string, opts ...CallOption) (TaskStatus, error)
+	DisableBackupWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DisableBackupAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	EnableBackup(id, intervalType, scheduleTime string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	EnableBackupWithContext(ctx context.Context, id, intervalType, scheduleTime string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	EnableBackupAsync(ctx context.Context, id, intervalType, scheduleTime string, opts ...CallOption) (*TaskHandle, error)
+	DisablePrivateNetwork(id string, opts ...CallOption) (TaskStatus, error)
+	DisablePrivateNetworkWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DisablePrivateNetworkAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	EnablePrivateNetwork(id string, opts ...CallOption) (TaskStatus, error)
+	EnablePrivateNetworkWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	EnablePrivateNetworkAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	ResetPassword(id string, opts ...CallOption) (TaskStatus, error)
+	ResetPasswordWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	ResetPasswordAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	Restart(id string, opts ...CallOption) (TaskStatus, error)
+	RestartWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	RestartAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	Stop(id string, opts ...CallOption) (TaskStatus, error)
+	StopWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	StopAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	Start(id string, opts ...CallOption) (TaskStatus, error)
+	StartWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	StartAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	RestoreSnapshot(id string, snapshotID string, opts ...CallOption) (TaskStatus, error)
+	RestoreSnapshotWithContext(ctx context.Context, id string, snapshotID string, opts ...CallOption) (TaskStatus, error)
+	RestoreSnapshotAsync(ctx context.Context, id, snapshotID string, opts ...CallOption) (*TaskHandle, error)
+	TakeSnapshot(id string, name string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	TakeSnapshotWithContext(ctx context.Context, id string, name string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	TakeSnapshotAsync(ctx context.Context, id string, name string, opts ...CallOption) (*TaskHandle, error)
+	Resize(id string, cpu, ramGb, rootGb, gpu int, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	ResizeWithContext(ctx context.Context, id string, cpu, ramGb, rootGb, gpu int, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	ResizeAsync(ctx context.Context, id string, cpu, ramGb, rootGb, gpu int, opts ...CallOption) (*TaskHandle, error)
 	GetConsoleURL(id string) (string, error)
+	GetConsoleURLWithContext(ctx context.Context, id string) (string, error)
 	Rename(id, newName string) (string, error)
+	RenameWithContext(ctx context.Context, id, newName string) (string, error)
 	UpdateScheduleTime(id, intervalType, scheduleTime string) (string, error)
+	UpdateScheduleTimeWithContext(ctx context.Context, id, intervalType, scheduleTime string) (string, error)
 }
 
 // Nic object
@@ -69,6 +108,7 @@ type Server struct {
 	TotalDatadiskSize int           `json:"total_datadisk_size"`
 	Jobs              []interface{} `json:"jobs"`
 	Demo              bool          `json:"demo"`
+	Location          Region        `json:"-"` // Region of the server, from RegionID and RegionName
 }
 
 type server struct {
@@ -77,7 +117,12 @@ type server struct {
 
 // Get server detail
 func (s *server) Get(id string) (Server, error) {
-	jsonStr, err := s.client.Get("server/info", map[string]string{"id": id})
+	return s.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (s *server) GetWithContext(ctx context.Context, id string) (Server, error) {
+	jsonStr, err := s.client.GetWithContext(ctx, "server/info", map[string]string{"id": id})
 	var server Server
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &server)
@@ -86,62 +131,235 @@ func (s *server) Get(id string) (Server, error) {
 }
 
 // Delete a server
-func (s *server) Delete(id string) (TaskStatus, error) {
-	return s.client.LongDeleteTask("server_action/delete", id, nil, LongTimeSettings)
+func (s *server) Delete(id string, opts ...CallOption) (TaskStatus, error) {
+	return s.DeleteWithContext(context.Background(), id, opts...)
+}
+
+// DeleteWithContext same as Delete, cancellable through ctx
+func (s *server) DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.DeleteAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DeleteAsync same as Delete, returns once the task is submitted
+func (s *server) DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongDeleteTaskAsync(ctx, "server_action/delete", id, nil, LongTimeSettings, opts...)
 }
 func (s *server) Rename(id, newName string) (string, error) {
-	return s.client.Post("server_action/rename", map[string]interface{}{"id": id, "name": newName})
+	return s.RenameWithContext(context.Background(), id, newName)
+}
+
+func (s *server) RenameWithContext(ctx context.Context, id, newName string) (string, error) {
+	return s.client.PostWithContext(ctx, "server_action/rename", map[string]interface{}{"id": id, "name": newName})
 }
 func (s *server) UpdateScheduleTime(id, intervalType, scheduleTime string) (string, error) {
-	return s.client.Post("server_action/update_schedule_time", map[string]interface{}{"id": id, "interval_type": intervalType, "schedule_time": scheduleTime})
+	return s.UpdateScheduleTimeWithContext(context.Background(), id, intervalType, scheduleTime)
+}
+
+func (s *server) UpdateScheduleTimeWithContext(ctx context.Context, id, intervalType, scheduleTime string) (string, error) {
+	return s.client.PostWithContext(ctx, "server_action/update_schedule_time", map[string]interface{}{"id": id, "interval_type": intervalType, "schedule_time": scheduleTime})
+}
+func (s *server) AddSecondaryIP(id string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return s.AddSecondaryIPWithContext(context.Background(), id, opts...)
+}
+
+func (s *server) AddSecondaryIPWithContext(ctx context.Context, id string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := s.AddSecondaryIPAsync(ctx, id, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// AddSecondaryIPAsync same as AddSecondaryIP, returns once the task is submitted
+func (s *server) AddSecondaryIPAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.OrderAsync(ctx, "server_action/add_secondary_ip", id, nil, MediumTimeSettings, opts...)
+}
+func (s *server) RemoveSecondaryIP(id, ip4Address string, opts ...CallOption) (TaskStatus, error) {
+	return s.RemoveSecondaryIPWithContext(context.Background(), id, ip4Address, opts...)
+}
+
+func (s *server) RemoveSecondaryIPWithContext(ctx context.Context, id, ip4Address string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.RemoveSecondaryIPAsync(ctx, id, ip4Address, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// RemoveSecondaryIPAsync same as RemoveSecondaryIP, returns once the task is submitted
+func (s *server) RemoveSecondaryIPAsync(ctx context.Context, id, ip4Address string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/remove_secondary_ip", id, map[string]interface{}{"ip4_address": ip4Address}, MediumTimeSettings, opts...)
+}
+func (s *server) AddNic(id, networkID string, opts ...CallOption) (TaskStatus, error) {
+	return s.AddNicWithContext(context.Background(), id, networkID, opts...)
+}
+
+func (s *server) AddNicWithContext(ctx context.Context, id, networkID string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.AddNicAsync(ctx, id, networkID, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// AddNicAsync same as AddNic, returns once the task is submitted
+func (s *server) AddNicAsync(ctx context.Context, id, networkID string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/add_nic", id, map[string]interface{}{"network_id": networkID}, MediumTimeSettings, opts...)
+}
+func (s *server) RemoveNic(id, nicID string, opts ...CallOption) (TaskStatus, error) {
+	return s.RemoveNicWithContext(context.Background(), id, nicID, opts...)
+}
+
+func (s *server) RemoveNicWithContext(ctx context.Context, id, nicID string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.RemoveNicAsync(ctx, id, nicID, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// RemoveNicAsync same as RemoveNic, returns once the task is submitted
+func (s *server) RemoveNicAsync(ctx context.Context, id, nicID string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/remove_nic", id, map[string]interface{}{"nic_id": nicID}, MediumTimeSettings, opts...)
+}
+func (s *server) DisableBackup(id string, opts ...CallOption) (TaskStatus, error) {
+	return s.DisableBackupWithContext(context.Background(), id, opts...)
+}
+
+func (s *server) DisableBackupWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.DisableBackupAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DisableBackupAsync same as DisableBackup, returns once the task is submitted
+func (s *server) DisableBackupAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/disable_backup", id, nil, ShortTimeSettings, opts...)
+}
+func (s *server) EnableBackup(id, intervalType, scheduleTime string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return s.EnableBackupWithContext(context.Background(), id, intervalType, scheduleTime, opts...)
+}
+
+func (s *server) EnableBackupWithContext(ctx context.Context, id, intervalType, scheduleTime string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := s.EnableBackupAsync(ctx, id, intervalType, scheduleTime, opts...)
+	return waitOrder(ctx, handle, err)
 }
-func (s *server) AddSecondaryIP(id string) (OrderResponse, TaskStatus, error) {
-	return s.client.Order("server_action/add_secondary_ip", id, nil, MediumTimeSettings)
+
+// EnableBackupAsync same as EnableBackup, returns once the task is submitted
+func (s *server) EnableBackupAsync(ctx context.Context, id, intervalType, scheduleTime string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.OrderAsync(ctx, "server_action/enable_backup", id, map[string]interface{}{"interval_type": intervalType, "schedule_time": scheduleTime}, ShortTimeSettings, opts...)
+}
+func (s *server) DisablePrivateNetwork(id string, opts ...CallOption) (TaskStatus, error) {
+	return s.DisablePrivateNetworkWithContext(context.Background(), id, opts...)
+}
+
+func (s *server) DisablePrivateNetworkWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.DisablePrivateNetworkAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DisablePrivateNetworkAsync same as DisablePrivateNetwork, returns once the task is submitted
+func (s *server) DisablePrivateNetworkAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/disable_private_network", id, nil, MediumTimeSettings, opts...)
+}
+func (s *server) EnablePrivateNetwork(id string, opts ...CallOption) (TaskStatus, error) {
+	return s.EnablePrivateNetworkWithContext(context.Background(), id, opts...)
+}
+
+func (s *server) EnablePrivateNetworkWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.EnablePrivateNetworkAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// EnablePrivateNetworkAsync same as EnablePrivateNetwork, returns once the task is submitted
+func (s *server) EnablePrivateNetworkAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/enable_private_network", id, nil, MediumTimeSettings, opts...)
+}
+func (s *server) ResetPassword(id string, opts ...CallOption) (TaskStatus, error) {
+	return s.ResetPasswordWithContext(context.Background(), id, opts...)
+}
+
+func (s *server) ResetPasswordWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.ResetPasswordAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// ResetPasswordAsync same as ResetPassword, returns once the task is submitted
+func (s *server) ResetPasswordAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/reset_pass", id, nil, MediumTimeSettings, opts...)
+}
+func (s *server) Restart(id string, opts ...CallOption) (TaskStatus, error) {
+	return s.RestartWithContext(context.Background(), id, opts...)
+}
+
+func (s *server) RestartWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.RestartAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
 }
-func (s *server) RemoveSecondaryIP(id, ip4Address string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/remove_secondary_ip", id, map[string]interface{}{"ip4_address": ip4Address}, MediumTimeSettings)
+
+// RestartAsync same as Restart, returns once the task is submitted
+func (s *server) RestartAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/restart", id, nil, LongTimeSettings, opts...)
 }
-func (s *server) AddNic(id, networkID string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/add_nic", id, map[string]interface{}{"network_id": networkID}, MediumTimeSettings)
+func (s *server) Stop(id string, opts ...CallOption) (TaskStatus, error) {
+	return s.StopWithContext(context.Background(), id, opts...)
 }
-func (s *server) RemoveNic(id, nicID string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/remove_nic", id, map[string]interface{}{"nic_id": nicID}, MediumTimeSettings)
+
+func (s *server) StopWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.StopAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
 }
-func (s *server) DisableBackup(id string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/disable_backup", id, nil, ShortTimeSettings)
+
+// StopAsync same as Stop, returns once the task is submitted
+func (s *server) StopAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/stop", id, nil, LongTimeSettings, opts...)
 }
-func (s *server) EnableBackup(id, intervalType, scheduleTime string) (OrderResponse, TaskStatus, error) {
-	return s.client.Order("server_action/enable_backup", id, map[string]interface{}{"interval_type": intervalType, "schedule_time": scheduleTime}, ShortTimeSettings)
+func (s *server) Start(id string, opts ...CallOption) (TaskStatus, error) {
+	return s.StartWithContext(context.Background(), id, opts...)
 }
-func (s *server) DisablePrivateNetwork(id string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/disable_private_network", id, nil, MediumTimeSettings)
+
+func (s *server) StartWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.StartAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
 }
-func (s *server) EnablePrivateNetwork(id string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/enable_private_network", id, nil, MediumTimeSettings)
+
+// StartAsync same as Start, returns once the task is submitted
+func (s *server) StartAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/start", id, nil, LongTimeSettings, opts...)
 }
-func (s *server) ResetPassword(id string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/reset_pass", id, nil, MediumTimeSettings)
+func (s *server) RestoreSnapshot(id, snapshotID string, opts ...CallOption) (TaskStatus, error) {
+	return s.RestoreSnapshotWithContext(context.Background(), id, snapshotID, opts...)
 }
-func (s *server) Restart(id string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/restart", id, nil, LongTimeSettings)
+
+func (s *server) RestoreSnapshotWithContext(ctx context.Context, id, snapshotID string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := s.RestoreSnapshotAsync(ctx, id, snapshotID, opts...)
+	return waitTask(ctx, handle, err)
 }
-func (s *server) Stop(id string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/stop", id, nil, LongTimeSettings)
+
+// RestoreSnapshotAsync same as RestoreSnapshot, returns once the task is submitted
+func (s *server) RestoreSnapshotAsync(ctx context.Context, id, snapshotID string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.LongTaskAsync(ctx, "server_action/restore_snapshot", id, map[string]interface{}{"snapshot_id": snapshotID}, SuperLongTimeSettings, opts...)
 }
-func (s *server) Start(id string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/start", id, nil, LongTimeSettings)
+func (s *server) TakeSnapshot(id string, name string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return s.TakeSnapshotWithContext(context.Background(), id, name, opts...)
 }
-func (s *server) RestoreSnapshot(id, snapshotID string) (TaskStatus, error) {
-	return s.client.LongTask("server_action/restore_snapshot", id, map[string]interface{}{"snapshot_id": snapshotID}, SuperLongTimeSettings)
+
+func (s *server) TakeSnapshotWithContext(ctx context.Context, id string, name string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := s.TakeSnapshotAsync(ctx, id, name, opts...)
+	return waitOrder(ctx, handle, err)
 }
-func (s *server) TakeSnapshot(id string, name string) (OrderResponse, TaskStatus, error) {
-	return s.client.Order("server_action/take_snapshot", id, map[string]interface{}{"name": name}, HalfDayTimeSettings)
+
+// TakeSnapshotAsync same as TakeSnapshot, returns once the task is submitted
+func (s *server) TakeSnapshotAsync(ctx context.Context, id string, name string, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.OrderAsync(ctx, "server_action/take_snapshot", id, map[string]interface{}{"name": name}, HalfDayTimeSettings, opts...)
 }
-func (s *server) Resize(id string, cpu, ramGb, rootGb, gpu int) (OrderResponse, TaskStatus, error) {
-	return s.client.Order("server_action/resize", id, map[string]interface{}{"cpu": cpu, "ram": ramGb, "disk": rootGb, "gpu": gpu}, LongTimeSettings)
+func (s *server) Resize(id string, cpu, ramGb, rootGb, gpu int, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return s.ResizeWithContext(context.Background(), id, cpu, ramGb, rootGb, gpu, opts...)
+}
+
+func (s *server) ResizeWithContext(ctx context.Context, id string, cpu, ramGb, rootGb, gpu int, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := s.ResizeAsync(ctx, id, cpu, ramGb, rootGb, gpu, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// ResizeAsync same as Resize, returns once the task is submitted
+func (s *server) ResizeAsync(ctx context.Context, id string, cpu, ramGb, rootGb, gpu int, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.OrderAsync(ctx, "server_action/resize", id, map[string]interface{}{"cpu": cpu, "ram": ramGb, "disk": rootGb, "gpu": gpu}, LongTimeSettings, opts...)
 }
 func (s *server) GetConsoleURL(id string) (string, error) {
-	jsonStr, err := s.client.Get("server_action/console", map[string]string{"id": id})
+	return s.GetConsoleURLWithContext(context.Background(), id)
+}
+
+func (s *server) GetConsoleURLWithContext(ctx context.Context, id string) (string, error) {
+	jsonStr, err := s.client.GetWithContext(ctx, "server_action/console", map[string]string{"id": id})
 	type Console struct {
 		URL string `json:"url"`
 	}
@@ -154,6 +372,17 @@ func (s *server) GetConsoleURL(id string) (string, error) {
 }
 
 // Create a new server
-func (s *server) Create(params map[string]interface{}) (OrderResponse, TaskStatus, error) {
-	return s.client.Order("server/create", "", params, LongTimeSettings)
+func (s *server) Create(params map[string]interface{}, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return s.CreateWithContext(context.Background(), params, opts...)
+}
+
+// CreateWithContext same as Create, cancellable through ctx
+func (s *server) CreateWithContext(ctx context.Context, params map[string]interface{}, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := s.CreateAsync(ctx, params, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// CreateAsync same as Create, returns once the task is submitted
+func (s *server) CreateAsync(ctx context.Context, params map[string]interface{}, opts ...CallOption) (*TaskHandle, error) {
+	return s.client.OrderAsync(ctx, "server/create", "", params, LongTimeSettings, opts...)
 }
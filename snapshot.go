@@ -1,15 +1,22 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
 )
 
 // SnapshotService interface
 type SnapshotService interface {
 	Get(id string) (Snapshot, error)
-	Create(volumeID string, name string) (OrderResponse, TaskStatus, error)
-	Delete(id string) (TaskStatus, error)
+	GetWithContext(ctx context.Context, id string) (Snapshot, error)
+	Create(volumeID string, name string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateWithContext(ctx context.Context, volumeID string, name string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateAsync(ctx context.Context, volumeID string, name string, opts ...CallOption) (*TaskHandle, error)
+	Delete(id string, opts ...CallOption) (TaskStatus, error)
+	DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
 	Rename(id string, newName string) error
+	RenameWithContext(ctx context.Context, id string, newName string) error
 }
 
 // Snapshot object
@@ -29,7 +36,12 @@ type snapshot struct {
 
 // Get snapshot detail
 func (v *snapshot) Get(id string) (Snapshot, error) {
-	jsonStr, err := v.client.Get("snapshot/info", map[string]string{"id": id})
+	return v.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (v *snapshot) GetWithContext(ctx context.Context, id string) (Snapshot, error) {
+	jsonStr, err := v.client.GetWithContext(ctx, "snapshot/info", map[string]string{"id": id})
 	var snapshot Snapshot
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &snapshot)
@@ -38,15 +50,41 @@ func (v *snapshot) Get(id string) (Snapshot, error) {
 }
 
 // Delete a snapshot
-func (v *snapshot) Delete(id string) (TaskStatus, error) {
-	return v.client.LongDeleteTask("snapshot/delete", id, nil, MediumTimeSettings)
+func (v *snapshot) Delete(id string, opts ...CallOption) (TaskStatus, error) {
+	return v.DeleteWithContext(context.Background(), id, opts...)
+}
+
+// DeleteWithContext same as Delete, cancellable through ctx
+func (v *snapshot) DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.DeleteAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DeleteAsync same as Delete, returns once the task is submitted
+func (v *snapshot) DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongDeleteTaskAsync(ctx, "snapshot/delete", id, nil, MediumTimeSettings, opts...)
 }
 func (v *snapshot) Rename(id string, newName string) error {
-	_, err := v.client.Post("snapshot/rename", map[string]interface{}{"id": id, "name": newName})
+	return v.RenameWithContext(context.Background(), id, newName)
+}
+
+func (v *snapshot) RenameWithContext(ctx context.Context, id string, newName string) error {
+	_, err := v.client.PostWithContext(ctx, "snapshot/rename", map[string]interface{}{"id": id, "name": newName})
 	return err
 }
 
 // Create a new snapshot
-func (v *snapshot) Create(volumeID string, name string) (OrderResponse, TaskStatus, error) {
-	return v.client.Order("snapshot/create", "", map[string]interface{}{"volume_id": volumeID, "name": name}, HalfDayTimeSettings)
+func (v *snapshot) Create(volumeID string, name string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return v.CreateWithContext(context.Background(), volumeID, name, opts...)
+}
+
+// CreateWithContext same as Create, cancellable through ctx
+func (v *snapshot) CreateWithContext(ctx context.Context, volumeID string, name string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := v.CreateAsync(ctx, volumeID, name, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// CreateAsync same as Create, returns once the task is submitted
+func (v *snapshot) CreateAsync(ctx context.Context, volumeID string, name string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.OrderAsync(ctx, "snapshot/create", "", map[string]interface{}{"volume_id": volumeID, "name": name}, HalfDayTimeSettings, opts...)
 }
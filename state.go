@@ -1,7 +1,7 @@
 package gocmcapi
 
 import (
-	"log"
+	"context"
 	"time"
 )
 
@@ -29,6 +29,12 @@ type StateChangeConf struct {
 	MinTimeout     time.Duration    // Smallest time to wait before refreshes
 	PollInterval   time.Duration    // Override MinTimeout/backoff and only poll this often
 	NotFoundChecks int              // Number of times to allow not found
+	Logger         Logger           // Receives progress messages, nil logs nothing
+
+	// Progress callbacks, called from the polling goroutine so they must not block
+	OnRefresh            func(state string, attempt int, next time.Duration) // After every refresh, next is 0 when waiting ends
+	OnTimeout            func(gracePeriod time.Duration)                     // Timeout reached, the last refresh may finish within gracePeriod
+	OnGracePeriodExpired func()                                              // The last refresh did not finish within the grace period
 
 	// This is to work around inconsistent APIs
 	ContinuousTargetOccurence int // Number of times the Target state has to occur continuously
@@ -49,7 +55,17 @@ type StateChangeConf struct {
 // Otherwise, the result is the result of the first call to the Refresh function to
 // reach the target state.
 func (conf *StateChangeConf) WaitForState() (interface{}, error) {
-	log.Printf("[DEBUG] Waiting for state to become: %s", conf.Target)
+	return conf.WaitForStateContext(context.Background())
+}
+
+// WaitForStateContext is the same as WaitForState, but stops polling and
+// returns ctx.Err() as soon as the context is cancelled.
+func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface{}, error) {
+	logger := conf.Logger
+	if logger == nil {
+		logger = NopLogger{}
+	}
+	logger.Debug("Waiting for state", "target", conf.Target)
 
 	notfoundTick := 0
 	targetOccurence := 0
@@ -80,10 +96,20 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 	go func() {
 		defer close(resCh)
 
-		time.Sleep(conf.Delay)
+		select {
+		case <-cancelCh:
+			return
+		case <-time.After(conf.Delay):
+		}
 
 		// start with 0 delay for the first loop
 		var wait time.Duration
+		attempt := 0
+		refreshed := func(state string, next time.Duration) {
+			if conf.OnRefresh != nil {
+				conf.OnRefresh(state, attempt, next)
+			}
+		}
 
 		for {
 			// store the last result
@@ -101,6 +127,7 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			}
 
 			res, currentState, err := conf.Refresh()
+			attempt++
 			result = Result{
 				Result: res,
 				State:  currentState,
@@ -108,6 +135,7 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			}
 
 			if err != nil {
+				refreshed(currentState, 0)
 				resCh <- result
 				return
 			}
@@ -116,10 +144,12 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			if res == nil && len(conf.Target) == 0 {
 				targetOccurence++
 				if conf.ContinuousTargetOccurence == targetOccurence {
+					refreshed(currentState, 0)
 					result.Done = true
 					resCh <- result
 					return
 				}
+				refreshed(currentState, wait)
 				continue
 			}
 
@@ -132,6 +162,7 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 						LastError: err,
 						Retries:   notfoundTick,
 					}
+					refreshed(currentState, 0)
 					resCh <- result
 					return
 				}
@@ -145,6 +176,7 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 						found = true
 						targetOccurence++
 						if conf.ContinuousTargetOccurence == targetOccurence {
+							refreshed(currentState, 0)
 							result.Done = true
 							resCh <- result
 							return
@@ -167,6 +199,7 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 						State:         result.State,
 						ExpectedState: conf.Target,
 					}
+					refreshed(currentState, 0)
 					resCh <- result
 					return
 				}
@@ -190,7 +223,8 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 				}
 			}
 
-			log.Printf("[TRACE] Waiting %s before next try", wait)
+			logger.Debug("Waiting before next try", "wait", wait)
+			refreshed(currentState, wait)
 		}
 	}()
 
@@ -214,9 +248,23 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			// still waiting, store the last result
 			lastResult = r
 
+		case <-ctx.Done():
+			logger.Warn("WaitForState cancelled", "error", ctx.Err())
+
+			// stop the refresh loop and let it drain in the background
+			close(cancelCh)
+			go func() {
+				for range resCh {
+				}
+			}()
+			return nil, ctx.Err()
+
 		case <-timeout:
-			log.Printf("[WARN] WaitForState timeout after %s", conf.Timeout)
-			log.Printf("[WARN] WaitForState starting %s refresh grace period", refreshGracePeriod)
+			logger.Warn("WaitForState timeout", "timeout", conf.Timeout)
+			logger.Warn("WaitForState starting refresh grace period", "grace_period", refreshGracePeriod)
+			if conf.OnTimeout != nil {
+				conf.OnTimeout(refreshGracePeriod)
+			}
 
 			// cancel the goroutine and start our grace period timer
 			close(cancelCh)
@@ -243,7 +291,10 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 					// TimeoutError and wait for the channel to close
 					lastResult = r
 				case <-timeout:
-					log.Println("[ERROR] WaitForState exceeded refresh grace period")
+					logger.Error("WaitForState exceeded refresh grace period")
+					if conf.OnGracePeriodExpired != nil {
+						conf.OnGracePeriodExpired()
+					}
 					break forSelect
 				}
 			}
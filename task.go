@@ -1,13 +1,14 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 )
 
 // TaskService interface
 type TaskService interface {
 	Get(uuid string) (TaskStatus, error)
+	GetWithContext(ctx context.Context, uuid string) (TaskStatus, error)
 }
 
 type task struct {
@@ -29,9 +30,17 @@ type TaskStatus struct {
 
 // Get task status
 func (t *task) Get(uuid string) (TaskStatus, error) {
-	jsonStr, err := t.client.Get("job/status", map[string]string{"id": uuid})
-	fmt.Println("jsonStr, err", jsonStr, err)
+	return t.GetWithContext(context.Background(), uuid)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (t *task) GetWithContext(ctx context.Context, uuid string) (TaskStatus, error) {
+	jsonStr, err := t.client.GetWithContext(ctx, taskStatusPath, map[string]string{"id": uuid})
+	t.client.logger.Debug("Task status", "task_id", uuid, "response", jsonStr, "error", err)
 	var task TaskStatus
+	if err != nil {
+		return task, err
+	}
 	err = json.Unmarshal([]byte(jsonStr), &task)
 	return task, err
 }
@@ -0,0 +1,137 @@
+package gocmcapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TaskEventKind is the kind of a TaskEvent
+type TaskEventKind string
+
+// Task event kinds
+const (
+	TaskEventPoll               TaskEventKind = "poll"                 // job/status was polled
+	TaskEventStateChange        TaskEventKind = "state_change"         // The status changed, e.g. WAIT to PROCESSING
+	TaskEventTimeout            TaskEventKind = "timeout"              // TimeSettings.Timeout reached, the grace period starts
+	TaskEventGracePeriodExpired TaskEventKind = "grace_period_expired" // The last poll did not finish within the grace period
+)
+
+// TaskEvent reports the progress of a task wait
+type TaskEvent struct {
+	Kind           TaskEventKind
+	TaskID         string
+	Status         string        // Status of the last successful poll, empty before it
+	PreviousStatus string        // Status before a state change
+	Attempt        int           // Number of polls so far
+	Elapsed        time.Duration // Time since the wait started
+	NextPollIn     time.Duration // Time before the next poll, 0 when the wait ends
+	GracePeriod    time.Duration // Grace period of a timeout
+}
+
+// TaskObserver receives the events of task waits, it is called from the
+// polling goroutine so it must not block
+type TaskObserver func(TaskEvent)
+
+type taskObserverCtx struct{}
+
+// WithTaskObserverContext returns a context whose task waits report their
+// progress to observer, in addition to the observer of WithTaskObserver
+func WithTaskObserverContext(ctx context.Context, observer TaskObserver) context.Context {
+	return context.WithValue(ctx, taskObserverCtx{}, observer)
+}
+
+// TaskEventChannel returns an observer sending the events to a channel of
+// size buffer, events are dropped while the channel is full
+func TaskEventChannel(buffer int) (TaskObserver, <-chan TaskEvent) {
+	ch := make(chan TaskEvent, buffer)
+	return func(event TaskEvent) {
+		select {
+		case ch <- event:
+		default:
+		}
+	}, ch
+}
+
+// taskEvents builds the TaskEvents of a wait from the StateChangeConf callbacks
+type taskEvents struct {
+	taskID    string
+	start     time.Time
+	observers []TaskObserver
+
+	mu      sync.Mutex
+	status  string
+	attempt int
+	closed  bool // The wait returned, a late poll is not reported
+}
+
+// newTaskEvents returns nil when nobody observes the wait
+func (c *Client) newTaskEvents(ctx context.Context, taskID string) *taskEvents {
+	var observers []TaskObserver
+	if c.taskObserver != nil {
+		observers = append(observers, c.taskObserver)
+	}
+	if observer, ok := ctx.Value(taskObserverCtx{}).(TaskObserver); ok && observer != nil {
+		observers = append(observers, observer)
+	}
+	if len(observers) == 0 {
+		return nil
+	}
+	return &taskEvents{taskID: taskID, start: time.Now(), observers: observers}
+}
+
+// attach sets the callbacks of conf
+func (e *taskEvents) attach(conf *StateChangeConf) {
+	if e == nil {
+		return
+	}
+	conf.OnRefresh = e.refreshed
+	conf.OnTimeout = func(gracePeriod time.Duration) {
+		e.emit(TaskEvent{Kind: TaskEventTimeout, GracePeriod: gracePeriod})
+	}
+	conf.OnGracePeriodExpired = func() {
+		e.emit(TaskEvent{Kind: TaskEventGracePeriodExpired})
+	}
+}
+
+func (e *taskEvents) refreshed(state string, attempt int, next time.Duration) {
+	e.mu.Lock()
+	previous := e.status
+	if state != "" {
+		e.status = state
+	}
+	e.attempt = attempt
+	e.mu.Unlock()
+
+	e.emit(TaskEvent{Kind: TaskEventPoll, NextPollIn: next})
+	if state != "" && previous != "" && state != previous {
+		e.emit(TaskEvent{Kind: TaskEventStateChange, PreviousStatus: previous, NextPollIn: next})
+	}
+}
+
+// close stops reporting events once the wait returned
+func (e *taskEvents) close() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+}
+
+// emit fills the common fields of event and sends it to the observers
+func (e *taskEvents) emit(event TaskEvent) {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return
+	}
+	event.TaskID = e.taskID
+	event.Status = e.status
+	event.Attempt = e.attempt
+	event.Elapsed = time.Since(e.start)
+	e.mu.Unlock()
+	for _, observer := range e.observers {
+		observer(event)
+	}
+}
@@ -0,0 +1,41 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+// A task pending for more polls than NotFoundChecks must not fail as not found
+func TestLongPendingTask(t *testing.T) {
+	cloud := fakecloud.New(fakecloud.WithJobPolls(30))
+	observer, events := gocmcapi.TaskEventChannel(100)
+	c := newFakeClient(t, cloud, gocmcapi.WithTaskObserver(observer))
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+	status, err := c.Server.StopWithContext(context.Background(), s.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != "DONE" {
+		t.Errorf("status = %s, want DONE", status.Status)
+	}
+	var changes []string
+	for len(events) > 0 {
+		if event := <-events; event.Kind == gocmcapi.TaskEventStateChange {
+			changes = append(changes, event.PreviousStatus+"->"+event.Status)
+		}
+	}
+	want := []string{"WAIT->PROCESSING", "PROCESSING->DONE"}
+	if len(changes) != len(want) {
+		t.Fatalf("state changes = %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("state changes = %v, want %v", changes, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package gocmcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// TaskHandle is a submitted long running task, returned by the Async
+// methods. Its TaskID can be persisted and the wait resumed later with
+// Client.ResumeTask.
+//
+// The span of the call lasts until the task finishes, a wait is cancelled or
+// Stop is called, the spans of the waits are its children
+type TaskHandle struct {
+	TaskID string        // Id of the task, empty when the task finished at submission
+	Action string        // Action which started the task, e.g. server/create
+	Order  OrderResponse // Order of the task, for orders
+
+	client       *Client
+	timeSettings TimeSettings
+	ids          []string              // Resource ids whose cache is invalidated once done
+	wrap         func(err error) error // Adds the action to errors of the wait
+	traceCtx     context.Context       // Context of the call span, nil without one
+	span         Span
+	endSpan      sync.Once
+
+	mu      sync.Mutex
+	done    chan struct{}
+	started bool
+	cancel  context.CancelFunc // Stops the background wait
+	status  TaskStatus
+	err     error
+}
+
+func newTaskHandle(c *Client, action, taskID string, timeSettings TimeSettings) *TaskHandle {
+	return &TaskHandle{
+		TaskID:       taskID,
+		Action:       action,
+		client:       c,
+		timeSettings: timeSettings,
+		wrap:         func(err error) error { return err },
+		span:         nopSpan{},
+		done:         make(chan struct{}),
+	}
+}
+
+// spanContext is a context whose values are looked up in the context of a
+// call span first, so the spans of a wait are children of the call span
+// while the wait is cancelled by its own context
+type spanContext struct {
+	context.Context
+	call context.Context
+}
+
+func (c spanContext) Value(key interface{}) interface{} {
+	if value := c.call.Value(key); value != nil {
+		return value
+	}
+	return c.Context.Value(key)
+}
+
+// waitContext returns the context of a wait cancelled by ctx
+func (h *TaskHandle) waitContext(ctx context.Context) context.Context {
+	if h.traceCtx == nil {
+		return ctx
+	}
+	return spanContext{Context: ctx, call: h.traceCtx}
+}
+
+// end ends the span of the call, once
+func (h *TaskHandle) end(err error) {
+	h.endSpan.Do(func() { h.span.End(err) })
+}
+
+// ResumeTask returns a handle to wait for a task submitted earlier, e.g. by
+// another process
+func (c *Client) ResumeTask(taskID string, timeSettings TimeSettings) *TaskHandle {
+	return newTaskHandle(c, "", taskID, timeSettings)
+}
+
+// submitted invalidates the cache of the resources of the task, they may
+// change before anyone waits for it
+func (h *TaskHandle) submitted() {
+	if h.client.cache != nil {
+		h.client.cache.invalidate(h.ids)
+	}
+}
+
+// finish records the result of the task and closes Done, only the first
+// result is kept
+func (h *TaskHandle) finish(status TaskStatus, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	select {
+	case <-h.done:
+		return
+	default:
+	}
+	h.status, h.err = status, err
+	close(h.done)
+	if status.Status != "" {
+		h.span.SetAttribute(AttrTaskStatus, status.Status)
+	}
+	h.end(err)
+	if status.Status == "DONE" || status.Status == "ERROR" {
+		h.client.journalRemove(h.TaskID)
+	}
+	if h.client.cache != nil {
+		h.client.cache.invalidate(h.ids)
+	}
+}
+
+// Wait waits for the task to finish and returns its status. Cancelling ctx
+// stops waiting but not the task, Wait can be called again
+func (h *TaskHandle) Wait(ctx context.Context) (TaskStatus, error) {
+	h.mu.Lock()
+	background := h.started
+	h.mu.Unlock()
+	if background {
+		// Done already waits for the task
+		select {
+		case <-h.done:
+			return h.Result()
+		case <-ctx.Done():
+			return TaskStatus{}, ctx.Err()
+		}
+	}
+	select {
+	case <-h.done:
+		return h.Result()
+	default:
+	}
+	status, err := h.client.waitForTaskFinished(h.waitContext(ctx), h.Action, h.TaskID, h.timeSettings)
+	if err != nil {
+		if ctx.Err() != nil {
+			h.end(ctx.Err())
+			return status, ctx.Err()
+		}
+		err = h.wrap(err)
+	}
+	h.finish(status, err)
+	return h.Result()
+}
+
+func (h *TaskHandle) wait(ctx context.Context) {
+	status, err := h.client.waitForTaskFinished(h.waitContext(ctx), h.Action, h.TaskID, h.timeSettings)
+	if err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		} else {
+			err = h.wrap(err)
+		}
+	}
+	h.finish(status, err)
+}
+
+// Poll gets the task status once, without waiting
+func (h *TaskHandle) Poll() (TaskStatus, error) {
+	return h.PollWithContext(context.Background())
+}
+
+// PollWithContext same as Poll, cancellable through ctx
+func (h *TaskHandle) PollWithContext(ctx context.Context) (TaskStatus, error) {
+	select {
+	case <-h.done:
+		return h.Result()
+	default:
+	}
+	status, err := h.client.Task.GetWithContext(ctx, h.TaskID)
+	if err != nil {
+		return status, err
+	}
+	switch status.Status {
+	case "DONE":
+		h.finish(status, nil)
+	case "ERROR":
+		h.finish(status, h.wrap(errors.New(fmt.Sprint(status))))
+	}
+	return status, nil
+}
+
+// Done returns a channel closed when the task finished, the first call
+// starts waiting for it in the background. Result returns the outcome
+func (h *TaskHandle) Done() <-chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.started {
+		h.started = true
+		select {
+		case <-h.done:
+		default:
+			var ctx context.Context
+			ctx, h.cancel = context.WithCancel(context.Background())
+			go h.wait(ctx)
+		}
+	}
+	return h.done
+}
+
+// Stop stops waiting for the task, but not the task. Done is closed and
+// Result returns context.Canceled if the task did not finish yet, use
+// Client.ResumeTask to wait for it again
+func (h *TaskHandle) Stop() {
+	h.mu.Lock()
+	cancel := h.cancel
+	h.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	h.finish(TaskStatus{}, context.Canceled)
+}
+
+// Result returns the status of the finished task, a zero TaskStatus and a
+// nil error while it is running
+func (h *TaskHandle) Result() (TaskStatus, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status, h.err
+}
+
+// waitTask waits for the handle returned with err by an Async method
+func waitTask(ctx context.Context, handle *TaskHandle, err error) (TaskStatus, error) {
+	if err != nil {
+		return TaskStatus{}, err
+	}
+	return handle.Wait(ctx)
+}
+
+// waitOrder waits for the handle returned with err by an Async order
+// method, the order is returned even when it is not paid
+func waitOrder(ctx context.Context, handle *TaskHandle, err error) (OrderResponse, TaskStatus, error) {
+	if handle == nil {
+		return OrderResponse{}, TaskStatus{}, err
+	}
+	if err != nil {
+		return handle.Order, TaskStatus{}, err
+	}
+	status, err := handle.Wait(ctx)
+	return handle.Order, status, err
+}
@@ -0,0 +1,194 @@
+package gocmcapi_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cmc-cloud/gocmcapi"
+	"github.com/cmc-cloud/gocmcapi/fakecloud"
+)
+
+// recordingTracer records the parent of every span
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	tracer *recordingTracer
+	name   string
+	parent *recordedSpan
+	ended  time.Time
+}
+
+type spanKey struct{}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) (context.Context, gocmcapi.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*recordedSpan)
+	span := &recordedSpan{tracer: t, name: name, parent: parent}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) {}
+
+func (s *recordedSpan) End(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.ended = time.Now()
+}
+
+func (t *recordingTracer) span(name string) *recordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, span := range t.spans {
+		if span.name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestTaskWaitSpanIsChildOfCallSpan(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		tracer := &recordingTracer{}
+		cloud := fakecloud.New()
+		c := newFakeClient(t, cloud, gocmcapi.WithTracer(tracer))
+		s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+
+		if async {
+			handle, err := c.Server.StopAsync(context.Background(), s.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			<-handle.Done()
+		} else if _, err := c.Server.Stop(s.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		call, wait := tracer.span("server_action/stop"), tracer.span("wait task")
+		if call == nil || wait == nil {
+			t.Fatalf("async %v: spans %v", async, tracer.spans)
+		}
+		if call.parent != nil || wait.parent != call {
+			t.Errorf("async %v: call span parent %v, wait span parent %v", async, call.parent, wait.parent)
+		}
+		tracer.mu.Lock()
+		if call.ended.IsZero() || call.ended.Before(wait.ended) {
+			t.Errorf("async %v: call span ended at %v, before the wait span at %v", async, call.ended, wait.ended)
+		}
+		tracer.mu.Unlock()
+	}
+}
+
+func TestTaskHandleStop(t *testing.T) {
+	cloud := fakecloud.New(fakecloud.WithJobPolls(1 << 30))
+	c := newFakeClient(t, cloud)
+	s := cloud.AddServer(gocmcapi.Server{Name: "web", State: "running"})
+	handle, err := c.Server.StopAsync(context.Background(), s.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := handle.Done()
+	for count(cloud, "job/status") < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	handle.Stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Done was not closed by Stop")
+	}
+	if _, err := handle.Result(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Result() error = %v, want context.Canceled", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	polls := count(cloud, "job/status")
+	time.Sleep(20 * time.Millisecond)
+	if n := count(cloud, "job/status"); n != polls {
+		t.Errorf("still polling after Stop: %d polls, then %d", polls, n)
+	}
+}
+
+func TestDeleteAllRulesAsync(t *testing.T) {
+	cloud := fakecloud.New()
+	c := newFakeClient(t, cloud)
+	_, status, err := c.VPC.Create("net", "", "hn", "10.0.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err = c.FirewallVPC.Create(status.ResultID, "fw", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	firewallID := status.ResultID
+	for i := 1; i <= 3; i++ {
+		if _, err := c.FirewallVPC.CreateRule(firewallID, i, "0.0.0.0/0", "allow", "tcp", "inbound", "22"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handles, err := c.FirewallVPC.DeleteAllRulesAsync(context.Background(), firewallID, gocmcapi.WithTimeSettings(gocmcapi.TimeSettings{Timeout: 7}))
+	if err != nil || len(handles) != 3 {
+		t.Fatalf("DeleteAllRulesAsync = %d handles, %v", len(handles), err)
+	}
+	for _, handle := range handles {
+		if settings := handle.TimeSettings(); settings.Timeout != 7 || settings.Interval != gocmcapi.ShortTimeSettings.Interval {
+			t.Errorf("time settings = %+v, want the call option over ShortTimeSettings", settings)
+		}
+		if _, err := handle.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rules, err := c.FirewallVPC.GetRules(firewallID); err != nil || len(rules) != 0 {
+		t.Errorf("rules = %v, %v", rules, err)
+	}
+}
+
+func TestDeleteAllRulesWaitsAfterFailure(t *testing.T) {
+	cloud := fakecloud.New()
+	journal := gocmcapi.NewMemoryJournal()
+	c := newFakeClient(t, cloud, gocmcapi.WithTaskJournal(journal))
+	_, status, err := c.VPC.Create("net", "", "hn", "10.0.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err = c.FirewallVPC.Create(status.ResultID, "fw", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	firewallID := status.ResultID
+	for i := 1; i <= 4; i++ {
+		if _, err := c.FirewallVPC.CreateRule(firewallID, i, "0.0.0.0/0", "allow", "tcp", "inbound", "22"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the first deletion fails, the last one is never submitted
+	cloud.InjectFault(fakecloud.Fault{Path: "firewall_vpc/delete_rule", TaskError: "rule in use", Times: 1})
+	deletes := 0
+	c.OnBeforeRequest(func(ctx context.Context, req *gocmcapi.RequestInfo) error {
+		if req.Path == "firewall_vpc/delete_rule" {
+			if deletes++; deletes == 4 {
+				return errors.New("hook failed")
+			}
+		}
+		return nil
+	})
+	err = c.FirewallVPC.DeleteAllRules(firewallID)
+	if err == nil || !strings.Contains(err.Error(), "hook failed") || !strings.Contains(err.Error(), "rule in use") {
+		t.Errorf("DeleteAllRules: %v, want both failures", err)
+	}
+	if firewall, _ := cloud.FirewallVPC(firewallID); len(firewall.InboundRules) != 2 {
+		t.Errorf("%d rules left, want the failed and the unsubmitted one", len(firewall.InboundRules))
+	}
+	if entries, _ := journal.List(); len(entries) != 0 {
+		t.Errorf("%d journal entries left, want every submitted deletion finished", len(entries))
+	}
+}
@@ -0,0 +1,66 @@
+package gocmcapi
+
+import (
+	"context"
+	"sync"
+)
+
+// Tracer starts the spans of api calls and task waits, see the otel module
+// for an OpenTelemetry implementation
+type Tracer interface {
+	// Start starts a span, the returned context carries it so spans started
+	// with it are its children
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a traced operation
+type Span interface {
+	SetAttribute(key string, value interface{})
+	// End finishes the span, err is the error of the operation or nil
+	End(err error)
+}
+
+// Span attribute keys
+const (
+	AttrEndpoint    = "cmc.endpoint"     // Api path, e.g. server_action/resize
+	AttrMethod      = "http.method"      // Http method
+	AttrStatusCode  = "http.status_code" // Http status of the response
+	AttrAttempt     = "cmc.attempt"      // Attempt number of a retried request
+	AttrResourceID  = "cmc.resource_id"  // Id of the resource the call is about
+	AttrTaskID      = "cmc.task_id"      // Id of the task
+	AttrTaskPolls   = "cmc.task.polls"   // Number of job/status polls
+	AttrTaskStatus  = "cmc.task.status"  // Last status of the task
+	AttrTaskCommand = "cmc.task.command" // Command of the task
+)
+
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttribute(key string, value interface{}) {}
+func (nopSpan) End(err error)                              {}
+
+// taskPolls records the polls of a task wait, it is updated by the refresh
+// goroutine so it is guarded by a mutex
+type taskPolls struct {
+	mu     sync.Mutex
+	count  int
+	status TaskStatus
+}
+
+func (p *taskPolls) add(status TaskStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count++
+	p.status = status
+}
+
+func (p *taskPolls) get() (int, TaskStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.count, p.status
+}
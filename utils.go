@@ -6,6 +6,9 @@ import (
 )
 
 // Logo log object
+//
+// Deprecated: it appends to log.txt in the working directory, set a Logger
+// with WithLogger instead
 func Logo(object interface{}) {
 	f, err := os.OpenFile("log.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -18,6 +21,9 @@ func Logo(object interface{}) {
 }
 
 // Logs log string
+//
+// Deprecated: it appends to log.txt in the working directory, set a Logger
+// with WithLogger instead
 func Logs(message string) {
 	f, err := os.OpenFile("log.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
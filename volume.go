@@ -1,18 +1,29 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
 )
 
 // VolumeService interface
 type VolumeService interface {
 	Get(id string) (Volume, error)
-	Create(params map[string]interface{}) (OrderResponse, TaskStatus, error)
-	Delete(id string) (TaskStatus, error)
-	Resize(id string, newSize int) (OrderResponse, TaskStatus, error)
+	GetWithContext(ctx context.Context, id string) (Volume, error)
+	Create(params map[string]interface{}, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateWithContext(ctx context.Context, params map[string]interface{}, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateAsync(ctx context.Context, params map[string]interface{}, opts ...CallOption) (*TaskHandle, error)
+	Delete(id string, opts ...CallOption) (TaskStatus, error)
+	DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	Resize(id string, newSize int, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	ResizeWithContext(ctx context.Context, id string, newSize int, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	ResizeAsync(ctx context.Context, id string, newSize int, opts ...CallOption) (*TaskHandle, error)
 	Rename(id string, newName string) error
+	RenameWithContext(ctx context.Context, id string, newName string) error
 	Attach(id string, serverID string) (string, error)
+	AttachWithContext(ctx context.Context, id string, serverID string) (string, error)
 	Detach(id string) (string, error)
+	DetachWithContext(ctx context.Context, id string) (string, error)
 }
 
 // Volume object
@@ -20,11 +31,13 @@ type Volume struct {
 	ID       string `json:"uuid"`
 	Name     string `json:"name"`
 	Region   string `json:"region"`
+	RegionID string `json:"region_id"`
 	Size     int    `json:"size"`
 	Type     string `json:"type"`
 	State    string `json:"state"`
 	Created  string `json:"created"`
 	ServerID string `json:"server_id"`
+	Location Region `json:"-"` // Region of the volume, from Region and RegionID
 }
 
 type volume struct {
@@ -33,7 +46,12 @@ type volume struct {
 
 // Get volume detail
 func (v *volume) Get(id string) (Volume, error) {
-	jsonStr, err := v.client.Get("volume/info", map[string]string{"id": id})
+	return v.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (v *volume) GetWithContext(ctx context.Context, id string) (Volume, error) {
+	jsonStr, err := v.client.GetWithContext(ctx, "volume/info", map[string]string{"id": id})
 	var volume Volume
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &volume)
@@ -42,24 +60,68 @@ func (v *volume) Get(id string) (Volume, error) {
 }
 
 // Delete a volume
-func (v *volume) Delete(id string) (TaskStatus, error) {
-	return v.client.LongDeleteTask("volume/delete", id, nil, MediumTimeSettings)
+func (v *volume) Delete(id string, opts ...CallOption) (TaskStatus, error) {
+	return v.DeleteWithContext(context.Background(), id, opts...)
+}
+
+// DeleteWithContext same as Delete, cancellable through ctx
+func (v *volume) DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.DeleteAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DeleteAsync same as Delete, returns once the task is submitted
+func (v *volume) DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongDeleteTaskAsync(ctx, "volume/delete", id, nil, MediumTimeSettings, opts...)
 }
 func (v *volume) Rename(id string, newName string) error {
-	_, err := v.client.Post("volume/rename", map[string]interface{}{"id": id, "name": newName})
+	return v.RenameWithContext(context.Background(), id, newName)
+}
+
+func (v *volume) RenameWithContext(ctx context.Context, id string, newName string) error {
+	_, err := v.client.PostWithContext(ctx, "volume/rename", map[string]interface{}{"id": id, "name": newName})
 	return err
 }
-func (v *volume) Resize(id string, newSize int) (OrderResponse, TaskStatus, error) {
-	return v.client.Order("volume/resize", id, map[string]interface{}{"size": newSize}, LongTimeSettings)
+func (v *volume) Resize(id string, newSize int, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return v.ResizeWithContext(context.Background(), id, newSize, opts...)
+}
+
+func (v *volume) ResizeWithContext(ctx context.Context, id string, newSize int, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := v.ResizeAsync(ctx, id, newSize, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// ResizeAsync same as Resize, returns once the task is submitted
+func (v *volume) ResizeAsync(ctx context.Context, id string, newSize int, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.OrderAsync(ctx, "volume/resize", id, map[string]interface{}{"size": newSize}, LongTimeSettings, opts...)
 }
 func (v *volume) Attach(id string, serverID string) (string, error) {
-	return v.client.Post("volume/attach", map[string]interface{}{"id": id, "server_id": serverID})
+	return v.AttachWithContext(context.Background(), id, serverID)
+}
+
+func (v *volume) AttachWithContext(ctx context.Context, id string, serverID string) (string, error) {
+	return v.client.PostWithContext(ctx, "volume/attach", map[string]interface{}{"id": id, "server_id": serverID})
 }
 func (v *volume) Detach(id string) (string, error) {
-	return v.client.Post("volume/detach", map[string]interface{}{"id": id})
+	return v.DetachWithContext(context.Background(), id)
+}
+
+func (v *volume) DetachWithContext(ctx context.Context, id string) (string, error) {
+	return v.client.PostWithContext(ctx, "volume/detach", map[string]interface{}{"id": id})
 }
 
 // Create a new volume
-func (v *volume) Create(params map[string]interface{}) (OrderResponse, TaskStatus, error) {
-	return v.client.Order("volume/create", "", params, LongTimeSettings)
+func (v *volume) Create(params map[string]interface{}, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return v.CreateWithContext(context.Background(), params, opts...)
+}
+
+// CreateWithContext same as Create, cancellable through ctx
+func (v *volume) CreateWithContext(ctx context.Context, params map[string]interface{}, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := v.CreateAsync(ctx, params, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// CreateAsync same as Create, returns once the task is submitted
+func (v *volume) CreateAsync(ctx context.Context, params map[string]interface{}, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.OrderAsync(ctx, "volume/create", "", params, LongTimeSettings, opts...)
 }
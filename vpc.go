@@ -1,15 +1,23 @@
 package gocmcapi
 
 import (
+	"context"
 	"encoding/json"
 )
 
 // VPCService interface
 type VPCService interface {
 	Get(id string) (VPC, error)
-	Create(name string, description string, region string, cidr string) (OrderResponse, TaskStatus, error)
-	Delete(id string) (TaskStatus, error)
-	Update(id string, name string, description string) error
+	GetWithContext(ctx context.Context, id string) (VPC, error)
+	Create(name string, description string, region string, cidr string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateWithContext(ctx context.Context, name string, description string, region string, cidr string, opts ...CallOption) (OrderResponse, TaskStatus, error)
+	CreateAsync(ctx context.Context, name string, description string, region string, cidr string, opts ...CallOption) (*TaskHandle, error)
+	Delete(id string, opts ...CallOption) (TaskStatus, error)
+	DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error)
+	DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error)
+	Update(id string, name string, description string, opts ...CallOption) error
+	UpdateWithContext(ctx context.Context, id string, name string, description string, opts ...CallOption) error
+	UpdateAsync(ctx context.Context, id string, name string, description string, opts ...CallOption) (*TaskHandle, error)
 }
 
 // VPC object
@@ -18,8 +26,10 @@ type VPC struct {
 	Name        string `json:"name"`
 	State       string `json:"state"`
 	RegionName  string `json:"zonename"`
+	RegionID    string `json:"zoneid"`
 	Cidr        string `json:"cidr"`
 	Description string `json:"description"`
+	Location    Region `json:"-"` // Region of the vpc, from RegionName and RegionID
 }
 type vpc struct {
 	client *Client
@@ -27,7 +37,12 @@ type vpc struct {
 
 // Get vpc detail
 func (v *vpc) Get(id string) (VPC, error) {
-	jsonStr, err := v.client.Get("vpc/info", map[string]string{"id": id})
+	return v.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext same as Get, cancellable through ctx
+func (v *vpc) GetWithContext(ctx context.Context, id string) (VPC, error) {
+	jsonStr, err := v.client.GetWithContext(ctx, "vpc/info", map[string]string{"id": id})
 	var vpc VPC
 	if err == nil {
 		err = json.Unmarshal([]byte(jsonStr), &vpc)
@@ -36,13 +51,44 @@ func (v *vpc) Get(id string) (VPC, error) {
 }
 
 // Delete a vpc
-func (v *vpc) Delete(id string) (TaskStatus, error) {
-	return v.client.LongDeleteTask("vpc/delete", id, nil, MediumTimeSettings)
+func (v *vpc) Delete(id string, opts ...CallOption) (TaskStatus, error) {
+	return v.DeleteWithContext(context.Background(), id, opts...)
+}
+
+// DeleteWithContext same as Delete, cancellable through ctx
+func (v *vpc) DeleteWithContext(ctx context.Context, id string, opts ...CallOption) (TaskStatus, error) {
+	handle, err := v.DeleteAsync(ctx, id, opts...)
+	return waitTask(ctx, handle, err)
+}
+
+// DeleteAsync same as Delete, returns once the task is submitted
+func (v *vpc) DeleteAsync(ctx context.Context, id string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongDeleteTaskAsync(ctx, "vpc/delete", id, nil, MediumTimeSettings, opts...)
+}
+func (v *vpc) Update(id string, name string, description string, opts ...CallOption) error {
+	return v.UpdateWithContext(context.Background(), id, name, description, opts...)
 }
-func (v *vpc) Update(id string, name string, description string) error {
-	_, err := v.client.LongTask("vpc", id, map[string]interface{}{"name": name, "description": description}, ShortTimeSettings)
+
+func (v *vpc) UpdateWithContext(ctx context.Context, id string, name string, description string, opts ...CallOption) error {
+	handle, err := v.UpdateAsync(ctx, id, name, description, opts...)
+	_, err = waitTask(ctx, handle, err)
 	return err
 }
-func (v *vpc) Create(name string, description string, region string, cidr string) (OrderResponse, TaskStatus, error) {
-	return v.client.Order("vpc/create", "", map[string]interface{}{"name": name, "description": description, "region": region, "cidr": cidr}, ShortTimeSettings)
+
+// UpdateAsync same as Update, returns once the task is submitted
+func (v *vpc) UpdateAsync(ctx context.Context, id string, name string, description string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.LongTaskAsync(ctx, "vpc", id, map[string]interface{}{"name": name, "description": description}, ShortTimeSettings, opts...)
+}
+func (v *vpc) Create(name string, description string, region string, cidr string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	return v.CreateWithContext(context.Background(), name, description, region, cidr, opts...)
+}
+
+func (v *vpc) CreateWithContext(ctx context.Context, name string, description string, region string, cidr string, opts ...CallOption) (OrderResponse, TaskStatus, error) {
+	handle, err := v.CreateAsync(ctx, name, description, region, cidr, opts...)
+	return waitOrder(ctx, handle, err)
+}
+
+// CreateAsync same as Create, returns once the task is submitted
+func (v *vpc) CreateAsync(ctx context.Context, name string, description string, region string, cidr string, opts ...CallOption) (*TaskHandle, error) {
+	return v.client.OrderAsync(ctx, "vpc/create", "", map[string]interface{}{"name": name, "description": description, "region": region, "cidr": cidr}, ShortTimeSettings, opts...)
 }